@@ -0,0 +1,349 @@
+package main
+
+/**
+ * dmetaphone
+ *
+ * Command line tool computing double metaphone keys for words given as arguments, read one
+ * per line from stdin, or read from a column of a CSV/TSV file.
+ *
+ * Usage:
+ *   dmetaphone [flags] [word ...]
+ *
+ * Examples:
+ *   dmetaphone Smith Schmidt
+ *   cat names.txt | dmetaphone -format csv
+ *   dmetaphone -file customers.csv -header -column surname -format json -max-length 6
+ *   dmetaphone -explain Schermerhorn
+ *
+ * -max-length limits the primary and alternate keys.  The short keys always hold the first
+ * four characters of the keys (METAPHONE_SHORT_KEY_LENGTH), whatever -max-length is.
+ */
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/CalypsoSys/godoublemetaphone/pkg/godoublemetaphone"
+)
+
+type record struct {
+	Word           string  `json:"word"`
+	Primary        string  `json:"primary"`
+	Alternate      *string `json:"alternate"`
+	PrimaryShort   uint16  `json:"primary_short"`
+	AlternateShort *uint16 `json:"alternate_short"`
+}
+
+type options struct {
+	format       string
+	maxKeyLength int
+	file         string
+	inputFormat  string
+	column       string
+	header       bool
+	explain      bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("dmetaphone", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	var opts options
+	flags.StringVar(&opts.format, "format", "text", "output format: text, json (one object per line) or csv")
+	flags.IntVar(&opts.maxKeyLength, "max-length", 0, "maximum length of the primary and alternate keys, 0 for unlimited; the short keys always hold the first 4 characters")
+	flags.StringVar(&opts.file, "file", "", "read words from a column of this CSV/TSV file")
+	flags.StringVar(&opts.inputFormat, "input-format", "", "format of -file: csv or tsv, by default from its extension")
+	flags.StringVar(&opts.column, "column", "1", "column of -file holding the words: 1-based number, or name with -header")
+	flags.BoolVar(&opts.header, "header", false, "first row of -file is a header")
+	flags.BoolVar(&opts.explain, "explain", false, "print the rules applied to each word as a table instead of its keys; cannot be combined with -format")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: dmetaphone [flags] [word ...]\n\nEncodes the words given as arguments, or -file, or else one word per line from stdin.\n\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	format := opts.format
+	if opts.explain {
+		formatSet := false
+		flags.Visit(func(f *flag.Flag) {
+			formatSet = formatSet || f.Name == "format"
+		})
+		if formatSet {
+			fmt.Fprintln(stderr, "dmetaphone: -explain cannot be combined with -format")
+			return 2
+		}
+		format = "explain"
+	}
+	out, err := newWriter(format, opts.maxKeyLength, stdout)
+	if err != nil {
+		fmt.Fprintln(stderr, "dmetaphone:", err)
+		return 2
+	}
+
+	emit := func(word string) error {
+		return out.write(encode(word, opts.maxKeyLength))
+	}
+
+	switch {
+	case flags.NArg() > 0:
+		for _, word := range flags.Args() {
+			if err = emit(word); err != nil {
+				break
+			}
+		}
+	case opts.file != "":
+		err = readFile(opts, emit)
+	default:
+		err = readLines(stdin, emit)
+	}
+
+	if err == nil {
+		err = out.flush()
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "dmetaphone:", err)
+		return 1
+	}
+
+	return 0
+}
+
+func encode(word string, maxKeyLength int) record {
+	dm := godoublemetaphone.NewDoubleMetaphoneLimit(word, keyLengthOrUnlimited(maxKeyLength))
+	sdm := godoublemetaphone.NewShortDoubleMetaphone(word)
+
+	rec := record{
+		Word:         word,
+		Primary:      dm.PrimaryKey(),
+		Alternate:    dm.AlternateKey(),
+		PrimaryShort: sdm.PrimaryShortKey(),
+	}
+	if alternateShort := sdm.AlternateShortKey(); alternateShort != godoublemetaphone.METAPHONE_INVALID_KEY {
+		rec.AlternateShort = &alternateShort
+	}
+
+	return rec
+}
+
+func keyLengthOrUnlimited(maxKeyLength int) int {
+	if maxKeyLength <= 0 {
+		return math.MaxInt64
+	}
+
+	return maxKeyLength
+}
+
+func readLines(in io.Reader, emit func(word string) error) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" {
+			continue
+		}
+		if err := emit(word); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func readFile(opts options, emit func(word string) error) error {
+	file, err := os.Open(opts.file)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	inputFormat := opts.inputFormat
+	if inputFormat == "" && strings.EqualFold(filepath.Ext(opts.file), ".tsv") {
+		inputFormat = "tsv"
+	}
+	switch inputFormat {
+	case "", "csv":
+	case "tsv":
+		reader.Comma = '\t'
+		reader.LazyQuotes = true
+	default:
+		return fmt.Errorf("unknown input format %q", inputFormat)
+	}
+
+	column, err := strconv.Atoi(opts.column)
+	column--
+	if opts.header {
+		header, err := reader.Read()
+		if err != nil {
+			return err
+		}
+		if column < 0 {
+			column = -1
+			for idx, name := range header {
+				if strings.EqualFold(strings.TrimSpace(name), opts.column) {
+					column = idx
+				}
+			}
+		} else if column >= len(header) {
+			return fmt.Errorf("column %d is beyond the %d columns of the header", column+1, len(header))
+		}
+	} else if err != nil {
+		return fmt.Errorf("column %q is not a number; use -header to select a column by name", opts.column)
+	}
+	if column < 0 {
+		return fmt.Errorf("column %q not found", opts.column)
+	}
+
+	for first := true; ; first = false {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		//a column beyond the first row is a typo, not a short row
+		if first && column >= len(row) {
+			return fmt.Errorf("column %d is beyond the %d columns of the first row", column+1, len(row))
+		}
+		if column >= len(row) || strings.TrimSpace(row[column]) == "" {
+			continue
+		}
+		if err := emit(strings.TrimSpace(row[column])); err != nil {
+			return err
+		}
+	}
+}
+
+type writer interface {
+	write(rec record) error
+	flush() error
+}
+
+func newWriter(format string, maxKeyLength int, out io.Writer) (writer, error) {
+	switch format {
+	case "text":
+		return &textWriter{out: bufio.NewWriter(out)}, nil
+	case "json":
+		buffered := bufio.NewWriter(out)
+		return &jsonWriter{out: buffered, encoder: json.NewEncoder(buffered)}, nil
+	case "csv":
+		return &csvWriter{out: csv.NewWriter(out)}, nil
+	case "explain":
+		return &explainWriter{out: bufio.NewWriter(out), maxKeyLength: keyLengthOrUnlimited(maxKeyLength)}, nil
+	}
+
+	return nil, fmt.Errorf("unknown output format %q", format)
+}
+
+type textWriter struct {
+	out *bufio.Writer
+}
+
+func (w *textWriter) write(rec record) error {
+	alternate, alternateShort := "-", "-"
+	if rec.Alternate != nil {
+		alternate = *rec.Alternate
+	}
+	if rec.AlternateShort != nil {
+		alternateShort = strconv.Itoa(int(*rec.AlternateShort))
+	}
+
+	_, err := fmt.Fprintf(w.out, "%s\t%s\t%s\t%d\t%s\n", rec.Word, rec.Primary, alternate, rec.PrimaryShort, alternateShort)
+	return err
+}
+
+func (w *textWriter) flush() error {
+	return w.out.Flush()
+}
+
+type jsonWriter struct {
+	out     *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (w *jsonWriter) write(rec record) error {
+	return w.encoder.Encode(rec)
+}
+
+func (w *jsonWriter) flush() error {
+	return w.out.Flush()
+}
+
+type csvWriter struct {
+	out           *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvWriter) write(rec record) error {
+	if !w.headerWritten {
+		w.headerWritten = true
+		if err := w.out.Write([]string{"word", "primary", "alternate", "primary_short", "alternate_short"}); err != nil {
+			return err
+		}
+	}
+
+	alternate, alternateShort := "", ""
+	if rec.Alternate != nil {
+		alternate = *rec.Alternate
+	}
+	if rec.AlternateShort != nil {
+		alternateShort = strconv.Itoa(int(*rec.AlternateShort))
+	}
+
+	return w.out.Write([]string{rec.Word, rec.Primary, alternate, strconv.Itoa(int(rec.PrimaryShort)), alternateShort})
+}
+
+func (w *csvWriter) flush() error {
+	w.out.Flush()
+	return w.out.Error()
+}
+
+type explainWriter struct {
+	out          *bufio.Writer
+	maxKeyLength int
+}
+
+func (w *explainWriter) write(rec record) error {
+	alternate := "-"
+	if rec.Alternate != nil {
+		alternate = *rec.Alternate
+	}
+	if _, err := fmt.Fprintf(w.out, "%s: primary %s, alternate %s\n", rec.Word, rec.Primary, alternate); err != nil {
+		return err
+	}
+
+	table := tabwriter.NewWriter(w.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "POS\tSPAN\tRULE\tPRIMARY\tALTERNATE")
+	for _, step := range godoublemetaphone.ExplainLimit(rec.Word, w.maxKeyLength) {
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\n", step.Position, step.Span, step.Rule, step.Primary, step.Alternate)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintln(w.out)
+	return err
+}
+
+func (w *explainWriter) flush() error {
+	return w.out.Flush()
+}
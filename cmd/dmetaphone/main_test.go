@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	csvFile := filepath.Join(dir, "names.csv")
+	tsvFile := filepath.Join(dir, "names.tsv")
+	if err := os.WriteFile(csvFile, []byte("id,surname\n1,Smith\n2,\"Van der Berg\"\n3,\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(tsvFile, []byte("1\tPeace\n2\tSchmidt\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		want     string
+		wantCode int
+	}{
+		{
+			name: "test arguments text",
+			args: []string{"Smith", "Peace"},
+			want: "Smith\tSM0\tXMT\t2686\t3451\nPeace\tPS\t-\t154\t-\n",
+		},
+		{
+			name:  "test stdin json",
+			args:  []string{"-format", "json"},
+			stdin: "Smith\n\n  Peace  \n",
+			want: `{"word":"Smith","primary":"SM0","alternate":"XMT","primary_short":2686,"alternate_short":3451}` + "\n" +
+				`{"word":"Peace","primary":"PS","alternate":null,"primary_short":154,"alternate_short":null}` + "\n",
+		},
+		{
+			name: "test csv column by name",
+			args: []string{"-file", csvFile, "-header", "-column", "surname", "-format", "csv", "-max-length", "2"},
+			want: "word,primary,alternate,primary_short,alternate_short\nSmith,SM,XM,2686,3451\nVan der Berg,FN,,10428,\n",
+		},
+		{
+			name: "test tsv column by number",
+			args: []string{"-file", tsvFile, "-column", "2"},
+			want: "Peace\tPS\t-\t154\t-\nSchmidt\tXMT\tSMT\t3451\t2683\n",
+		},
+		{
+			name: "test explain",
+			args: []string{"-explain", "Smith"},
+			want: "Smith: primary SM0, alternate XMT\n" +
+				"POS  SPAN  RULE                   PRIMARY  ALTERNATE\n" +
+				"0    S     germanic S, slavic SZ  S        X\n" +
+				"1    M     M                      M        M\n" +
+				"2    I     vowel                           \n" +
+				"3    TH    TH                     0        T\n\n",
+		},
+		{
+			name: "test explain max length",
+			args: []string{"-explain", "-max-length", "2", "Smith"},
+			want: "Smith: primary SM, alternate XM\n" +
+				"POS  SPAN  RULE                   PRIMARY  ALTERNATE\n" +
+				"0    S     germanic S, slavic SZ  S        X\n" +
+				"1    M     M                      M        M\n\n",
+		},
+		{
+			name:     "test unknown column",
+			args:     []string{"-file", csvFile, "-header", "-column", "forename"},
+			wantCode: 1,
+		},
+		{
+			name:     "test column beyond header",
+			args:     []string{"-file", csvFile, "-header", "-column", "5"},
+			wantCode: 1,
+		},
+		{
+			name:     "test column beyond first row",
+			args:     []string{"-file", tsvFile, "-column", "3"},
+			wantCode: 1,
+		},
+		{
+			name:     "test explain with format",
+			args:     []string{"-explain", "-format", "json", "Smith"},
+			wantCode: 2,
+		},
+		{
+			name:     "test unknown format",
+			args:     []string{"-format", "xml", "Smith"},
+			wantCode: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Fatalf("TestRun code = %d, want %d: %s", code, tt.wantCode, stderr.String())
+			}
+			if tt.wantCode == 0 && stdout.String() != tt.want {
+				t.Errorf("TestRun = %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
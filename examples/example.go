@@ -24,6 +24,9 @@ func main() {
 	dm = godoublemetaphone.NewDoubleMetaphone("Pace")
 	fmt.Printf("Metaphones for Prancers: primary: %s, alternate: %v\n", dm.PrimaryKey(), safeString(dm.AlternateKey()))
 
+	keys := godoublemetaphone.EncodeKeys("Smith")
+	fmt.Printf("Keys for Smith: %s, all: %v\n", keys, keys.All())
+
 	sdm := godoublemetaphone.NewShortDoubleMetaphone("Peace")
 	fmt.Printf("ShortMetaphones for Boxers: primary: %d, alternate: %d\n", sdm.PrimaryShortKey(), sdm.AlternateShortKey())
 
@@ -0,0 +1,193 @@
+package godoublemetaphone
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+/**
+ * batch.go
+ *
+ * Encodes whole lists or streams of words across a bounded pool of workers, each with its
+ * own reusable Encoder.  Results are always delivered in input order.
+ */
+
+/// BatchOptions configures EncodeAll and EncodeStream.  The zero value encodes with
+/// GOMAXPROCS workers, unlimited key length and no folding
+type BatchOptions struct {
+	///Number of concurrent workers, GOMAXPROCS when zero or negative
+	Workers int
+
+	///Maximum key length, unlimited when zero or negative
+	MaxKeyLength int
+
+	///Unicode folding applied to each word
+	Folding Folding
+}
+
+/// Result holds the keys computed for one word of a batch
+type Result struct {
+	///Position of the word in the input
+	Index int
+
+	Word         string
+	Primary      string
+	Alternate    string
+	HasAlternate bool
+
+	///Non-nil if the word could not be encoded: ErrInvalidUTF8, or the context's error
+	///if the batch was cancelled before the word was reached
+	Err error
+}
+
+func (opts BatchOptions) workers() int {
+	if opts.Workers > 0 {
+		return opts.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+func (opts BatchOptions) newEncoder() *Encoder {
+	maxKeyLength := opts.MaxKeyLength
+	if maxKeyLength <= 0 {
+		maxKeyLength = math.MaxInt64
+	}
+	return newEncoder(maxKeyLength, opts.Folding)
+}
+
+/// <summary>Encodes a single word of a batch with the worker's encoder</summary>
+func encodeResult(ctx context.Context, encoder *Encoder, index int, word string) Result {
+	result := Result{Index: index, Word: word}
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+	} else if !utf8.ValidString(word) {
+		result.Err = ErrInvalidUTF8
+	} else {
+		result.Primary, result.Alternate = encoder.Encode(word)
+		result.HasAlternate = encoder.dm.hasAlternate
+	}
+
+	return result
+}
+
+/// <summary>Encodes every word, fanning out across a bounded pool of workers</summary>
+///
+/// <param name="ctx">Context; once it is cancelled the remaining words are not encoded and
+///     their results carry the context's error</param>
+/// <param name="words">Words to encode</param>
+/// <param name="opts">Worker count, key length and folding</param>
+///
+/// <returns>One result per word, in the same order as words</returns>
+func EncodeAll(ctx context.Context, words []string, opts BatchOptions) []Result {
+	results := make([]Result, len(words))
+
+	workers := opts.workers()
+	if workers > len(words) {
+		workers = len(words)
+	}
+
+	var next int64 = -1
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			encoder := opts.newEncoder()
+			for {
+				index := int(atomic.AddInt64(&next, 1))
+				if index >= len(words) {
+					return
+				}
+				results[index] = encodeResult(ctx, encoder, index, words[index])
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+type streamJob struct {
+	index  int
+	word   string
+	result chan Result
+}
+
+/// <summary>Encodes words read from a channel, fanning out across a bounded pool of workers</summary>
+///
+/// <param name="ctx">Context; once it is cancelled no further words are read and the
+///     returned channel is closed</param>
+/// <param name="words">Words to encode; the returned channel is closed after words is closed
+///     and every word has been delivered</param>
+/// <param name="opts">Worker count, key length and folding</param>
+///
+/// <returns>Channel delivering one result per word, in the order the words were read</returns>
+func EncodeStream(ctx context.Context, words <-chan string, opts BatchOptions) <-chan Result {
+	workers := opts.workers()
+	jobs := make(chan streamJob)
+	//results waiting to be delivered in order; its capacity bounds the words in flight
+	pending := make(chan chan Result, 2*workers)
+	out := make(chan Result)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			encoder := opts.newEncoder()
+			for job := range jobs {
+				job.result <- encodeResult(ctx, encoder, job.index, job.word)
+			}
+		}()
+	}
+
+	//reads words and hands them to the workers, recording the order to deliver them in
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+		for index := 0; ; index++ {
+			var word string
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case word, ok = <-words:
+				if !ok {
+					return
+				}
+			}
+
+			job := streamJob{index: index, word: word, result: make(chan Result, 1)}
+			select {
+			case <-ctx.Done():
+				return
+			case pending <- job.result:
+			}
+			jobs <- job
+		}
+	}()
+
+	//delivers results in input order
+	go func() {
+		defer close(out)
+		cancelled := false
+		for result := range pending {
+			r := <-result
+			if cancelled {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				cancelled = true
+			case out <- r:
+			}
+		}
+		wg.Wait()
+	}()
+
+	return out
+}
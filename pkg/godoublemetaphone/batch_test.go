@@ -0,0 +1,130 @@
+package godoublemetaphone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func batchWords(count int) []string {
+	names := []string{"Smith", "Schmidt", "richard", "Jablonski", "Françoise", "Muñoz", "schermerhorn", "aubrey"}
+	words := make([]string, count)
+	for idx := range words {
+		words[idx] = fmt.Sprintf("%s%d", names[idx%len(names)], idx)
+	}
+	return words
+}
+
+func checkResult(t *testing.T, result Result, index int, word string, maxKeyLength int) {
+	want := NewDoubleMetaphoneLimit(word, maxKeyLength)
+	if result.Index != index || result.Word != word || result.Err != nil {
+		t.Errorf("checkResult = %d %s %v, want %d %s", result.Index, result.Word, result.Err, index, word)
+	}
+	if result.Primary != want.PrimaryKey() || result.HasAlternate != (want.AlternateKey() != nil) ||
+		(result.HasAlternate && result.Alternate != *want.AlternateKey()) {
+		t.Errorf("checkResult %s = %s %s, want %s %s", word, result.Primary, result.Alternate, want.PrimaryKey(), safeString(want.AlternateKey()))
+	}
+}
+
+func TestEncodeAll(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int
+		opts  BatchOptions
+	}{
+		{
+			name:  "test empty",
+			count: 0,
+			opts:  BatchOptions{},
+		},
+		{
+			name:  "test default workers",
+			count: 1000,
+			opts:  BatchOptions{},
+		},
+		{
+			name:  "test one worker",
+			count: 100,
+			opts:  BatchOptions{Workers: 1},
+		},
+		{
+			name:  "test more workers than words",
+			count: 3,
+			opts:  BatchOptions{Workers: 16, MaxKeyLength: METAPHONE_KEY_LENGTH},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words := batchWords(tt.count)
+			results := EncodeAll(context.Background(), words, tt.opts)
+			if len(results) != len(words) {
+				t.Fatalf("TestEncodeAll = %d results, want %d", len(results), len(words))
+			}
+			maxKeyLength := tt.opts.MaxKeyLength
+			if maxKeyLength == 0 {
+				maxKeyLength = 1 << 30
+			}
+			for idx, result := range results {
+				checkResult(t, result, idx, words[idx], maxKeyLength)
+			}
+		})
+	}
+}
+
+func TestEncodeAllInvalidUTF8(t *testing.T) {
+	results := EncodeAll(context.Background(), []string{"Smith", "Sm\xffith", "Jones"}, BatchOptions{Workers: 2})
+	if results[0].Err != nil || results[2].Err != nil {
+		t.Errorf("TestEncodeAllInvalidUTF8 = %v %v, want no error", results[0].Err, results[2].Err)
+	}
+	if !errors.Is(results[1].Err, ErrInvalidUTF8) || results[1].Primary != "" {
+		t.Errorf("TestEncodeAllInvalidUTF8 = %s %v, want ErrInvalidUTF8", results[1].Primary, results[1].Err)
+	}
+}
+
+func TestEncodeAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := EncodeAll(ctx, batchWords(50), BatchOptions{Workers: 4})
+	for _, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("TestEncodeAllCancelled %s = %v, want context.Canceled", result.Word, result.Err)
+		}
+	}
+}
+
+func TestEncodeStream(t *testing.T) {
+	words := batchWords(500)
+	in := make(chan string)
+	go func() {
+		for _, word := range words {
+			in <- word
+		}
+		close(in)
+	}()
+
+	index := 0
+	for result := range EncodeStream(context.Background(), in, BatchOptions{Workers: 4}) {
+		checkResult(t, result, index, words[index], 1<<30)
+		index++
+	}
+	if index != len(words) {
+		t.Errorf("TestEncodeStream = %d results, want %d", index, len(words))
+	}
+}
+
+func TestEncodeStreamCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	//never closed; only cancellation can end the stream
+	in := make(chan string, 10)
+	for _, word := range batchWords(10) {
+		in <- word
+	}
+
+	out := EncodeStream(ctx, in, BatchOptions{Workers: 2})
+	<-out
+	cancel()
+	for range out {
+	}
+}
@@ -0,0 +1,129 @@
+package godoublemetaphone
+
+import (
+	"testing"
+)
+
+var benchmarkCorpora = []struct {
+	name  string
+	words []string
+}{
+	{
+		name:  "short",
+		words: []string{"Smith", "Jones", "Brown", "Lee", "Wang", "Kim", "Ng", "Otto", "Bryan", "Hugh", "Zhao", "Ruiz"},
+	},
+	{
+		name: "long",
+		words: []string{"Schermerhorn", "Van der Berg", "Mary Ann Smith", "Wolfeschlegelsteinhausen", "Throckmorton-Featherstonehaugh",
+			"Bartholomew Cholmondeley", "San Jacinto", "Mac Caffrey", "Jablonski-Filipowicz", "Constantinopoulos"},
+	},
+	{
+		name:  "nonascii",
+		words: []string{"Françoise", "Muñoz", "Müller", "Søren", "Łukasz", "Ærøskøbing", "Dvořák", "Peña", "Çelik", "Jürgen", "andestādītu", "Straße"},
+	},
+}
+
+/// allocation budgets per call, checked by TestAllocationBudget so regressions in the rule engine show up
+const (
+	ALLOC_BUDGET_DOUBLE_METAPHONE       = 6
+	ALLOC_BUDGET_SHORT_DOUBLE_METAPHONE = 7
+	ALLOC_BUDGET_ENCODER_ENCODE         = 1
+	ALLOC_BUDGET_ENCODER_APPEND_ENCODE  = 0
+)
+
+func TestAllocationBudget(t *testing.T) {
+	for _, corpus := range benchmarkCorpora {
+		t.Run("test "+corpus.name, func(t *testing.T) {
+			encoder := NewEncoder()
+			buf := make([]byte, 0, 64)
+			//grow the encoder buffers to fit the longest word before measuring
+			for _, word := range corpus.words {
+				buf = encoder.AppendEncode(buf[:0], word)
+			}
+
+			for _, word := range corpus.words {
+				if got := testing.AllocsPerRun(10, func() { NewDoubleMetaphone(word) }); got > ALLOC_BUDGET_DOUBLE_METAPHONE {
+					t.Errorf("TestAllocationBudget NewDoubleMetaphone %s = %v allocs, want <= %d", word, got, ALLOC_BUDGET_DOUBLE_METAPHONE)
+				}
+				if got := testing.AllocsPerRun(10, func() { NewShortDoubleMetaphone(word) }); got > ALLOC_BUDGET_SHORT_DOUBLE_METAPHONE {
+					t.Errorf("TestAllocationBudget NewShortDoubleMetaphone %s = %v allocs, want <= %d", word, got, ALLOC_BUDGET_SHORT_DOUBLE_METAPHONE)
+				}
+				if got := testing.AllocsPerRun(10, func() { encoder.Encode(word) }); got > ALLOC_BUDGET_ENCODER_ENCODE {
+					t.Errorf("TestAllocationBudget Encoder.Encode %s = %v allocs, want <= %d", word, got, ALLOC_BUDGET_ENCODER_ENCODE)
+				}
+				if got := testing.AllocsPerRun(10, func() { buf = encoder.AppendEncode(buf[:0], word) }); got > ALLOC_BUDGET_ENCODER_APPEND_ENCODE {
+					t.Errorf("TestAllocationBudget Encoder.AppendEncode %s = %v allocs, want <= %d", word, got, ALLOC_BUDGET_ENCODER_APPEND_ENCODE)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNewDoubleMetaphone(b *testing.B) {
+	for _, corpus := range benchmarkCorpora {
+		words := corpus.words
+		b.Run(corpus.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				NewDoubleMetaphone(words[i%len(words)])
+			}
+		})
+	}
+}
+
+func BenchmarkNewDoubleMetaphoneLimit(b *testing.B) {
+	for _, corpus := range benchmarkCorpora {
+		words := corpus.words
+		b.Run(corpus.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				NewDoubleMetaphoneLimit(words[i%len(words)], METAPHONE_KEY_LENGTH)
+			}
+		})
+	}
+}
+
+func BenchmarkNewDoubleMetaphoneFolding(b *testing.B) {
+	for _, corpus := range benchmarkCorpora {
+		words := corpus.words
+		b.Run(corpus.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				NewDoubleMetaphoneFolding(words[i%len(words)], FOLD_ALL)
+			}
+		})
+	}
+}
+
+func BenchmarkNewShortDoubleMetaphone(b *testing.B) {
+	for _, corpus := range benchmarkCorpora {
+		words := corpus.words
+		b.Run(corpus.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				NewShortDoubleMetaphone(words[i%len(words)])
+			}
+		})
+	}
+}
+
+func BenchmarkEncoder(b *testing.B) {
+	for _, corpus := range benchmarkCorpora {
+		words := corpus.words
+		b.Run(corpus.name+"/Encode", func(b *testing.B) {
+			encoder := NewEncoder()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				encoder.Encode(words[i%len(words)])
+			}
+		})
+		b.Run(corpus.name+"/AppendEncode", func(b *testing.B) {
+			encoder := NewEncoder()
+			buf := make([]byte, 0, 64)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf = encoder.AppendEncode(buf[:0], words[i%len(words)])
+			}
+		})
+	}
+}
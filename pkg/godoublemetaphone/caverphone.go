@@ -0,0 +1,136 @@
+package godoublemetaphone
+
+import (
+	"regexp"
+	"strings"
+)
+
+/**
+ * caverphone.go
+ *
+ * Caverphone 1.0 and 2.0 by David Hood, Caversham Project, University of Otago, for
+ * matching names in New Zealand electoral rolls and similar Commonwealth records.  A name
+ * is rewritten by an ordered list of replacements and the result padded with '1' to a
+ * fixed length: CAVERPHONE1_KEY_LENGTH or CAVERPHONE2_KEY_LENGTH characters.  Only the
+ * letters A-Z are coded; fold a word with FoldWord first to code accented letters.
+ */
+
+const (
+	CAVERPHONE1_KEY_LENGTH = 6  //Length of a Caverphone 1.0 code
+	CAVERPHONE2_KEY_LENGTH = 10 //Length of a Caverphone 2.0 code
+)
+
+type Caverphone interface {
+	Key() string
+	Word() string
+}
+
+type caverphone struct {
+	keyString    string
+	originalWord string
+}
+
+/// A replacement applied to the whole name, in order
+type caverphoneRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+func caverphoneRules(pairs ...string) []caverphoneRule {
+	rules := make([]caverphoneRule, 0, len(pairs)/2)
+	for idx := 0; idx < len(pairs); idx += 2 {
+		rules = append(rules, caverphoneRule{pattern: regexp.MustCompile(pairs[idx]), replacement: pairs[idx+1]})
+	}
+
+	return rules
+}
+
+var caverphone1Rules = caverphoneRules(
+	"^cough", "cou2f", "^rough", "rou2f", "^tough", "tou2f", "^enough", "enou2f", "^gn", "2n", "mb$", "m2",
+	"cq", "2q", "ci", "si", "ce", "se", "cy", "sy", "tch", "2ch", "c", "k", "q", "k", "x", "k", "v", "f",
+	"dg", "2g", "tio", "sio", "tia", "sia", "d", "t", "ph", "fh", "b", "p", "sh", "s2", "z", "s",
+	"^[aeiou]", "A", "[aeiou]", "3",
+	"3gh3", "3kh3", "gh", "22", "g", "k",
+	"s+", "S", "t+", "T", "p+", "P", "k+", "K", "f+", "F", "m+", "M", "n+", "N",
+	"w3", "W3", "wy", "Wy", "wh3", "Wh3", "why", "Why", "w", "2",
+	"^h", "A", "h", "2",
+	"r3", "R3", "ry", "Ry", "r", "2",
+	"l3", "L3", "ly", "Ly", "l", "2",
+	"j", "y", "y3", "Y3", "y", "2",
+	"2", "", "3", "",
+)
+
+var caverphone2Rules = caverphoneRules(
+	"e$", "",
+	"^cough", "cou2f", "^rough", "rou2f", "^tough", "tou2f", "^enough", "enou2f", "^trough", "trou2f", "^gn", "2n", "mb$", "m2",
+	"cq", "2q", "ci", "si", "ce", "se", "cy", "sy", "tch", "2ch", "c", "k", "q", "k", "x", "k", "v", "f",
+	"dg", "2g", "tio", "sio", "tia", "sia", "d", "t", "ph", "fh", "b", "p", "sh", "s2", "z", "s",
+	"^[aeiou]", "A", "[aeiou]", "3",
+	"j", "y", "^y3", "Y3", "^y", "A", "y", "3",
+	"3gh3", "3kh3", "gh", "22", "g", "k",
+	"s+", "S", "t+", "T", "p+", "P", "k+", "K", "f+", "F", "m+", "M", "n+", "N",
+	"w3", "W3", "wh3", "Wh3", "w$", "3", "w", "2",
+	"^h", "A", "h", "2",
+	"r3", "R3", "r$", "3", "r", "2",
+	"l3", "L3", "l$", "3", "l", "2",
+	"2", "", "3$", "A", "3", "",
+)
+
+/// <summary>Computes the Caverphone 1.0 code, CAVERPHONE1_KEY_LENGTH characters</summary>
+func NewCaverphone1(word string) Caverphone {
+	return newCaverphone(word, caverphone1Rules, CAVERPHONE1_KEY_LENGTH)
+}
+
+/// <summary>Computes the Caverphone 2.0 code, CAVERPHONE2_KEY_LENGTH characters</summary>
+func NewCaverphone2(word string) Caverphone {
+	return newCaverphone(word, caverphone2Rules, CAVERPHONE2_KEY_LENGTH)
+}
+
+func newCaverphone(word string, rules []caverphoneRule, keyLength int) *caverphone {
+	return &caverphone{
+		keyString:    caverphoneKey(word, rules, keyLength),
+		originalWord: word,
+	}
+}
+
+/// <summary>The Caverphone code for the word, padded with '1'.  Empty if the word has no
+///     letters</summary>
+func (cp *caverphone) Key() string {
+	return cp.keyString
+}
+
+/// <summary>Original word for which the code was computed</summary>
+func (cp *caverphone) Word() string {
+	return cp.originalWord
+}
+
+/// CaverphoneEncoder is the PhoneticEncoder for Caverphone.  The zero value computes
+/// Caverphone 2.0 codes
+type CaverphoneEncoder struct {
+	///Compute Caverphone 1.0 codes instead
+	Version1 bool
+}
+
+func (enc CaverphoneEncoder) Keys(word string) []string {
+	if enc.Version1 {
+		return []string{caverphoneKey(word, caverphone1Rules, CAVERPHONE1_KEY_LENGTH)}
+	}
+
+	return []string{caverphoneKey(word, caverphone2Rules, CAVERPHONE2_KEY_LENGTH)}
+}
+
+/**
+* Internal impl of Caverphone on the lower case letters of the word
+ */
+func caverphoneKey(word string, rules []caverphoneRule, keyLength int) string {
+	letters := strings.ToLower(string(asciiUpperLetters(word)))
+	if letters == "" {
+		return ""
+	}
+
+	for _, rule := range rules {
+		letters = rule.pattern.ReplaceAllLiteralString(letters, rule.replacement)
+	}
+
+	return (letters + strings.Repeat("1", keyLength))[:keyLength]
+}
@@ -0,0 +1,173 @@
+package godoublemetaphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCaverphone1(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{
+			name: "test ABANICO",
+			arg:  "ABANICO",
+			want: "APNK11",
+		},
+		{
+			name: "test ACTIONABLE",
+			arg:  "ACTIONABLE",
+			want: "AKSNPL",
+		},
+		{
+			name: "test Lee",
+			arg:  "Lee",
+			want: "L11111",
+		},
+		{
+			name: "test Stevenson",
+			arg:  "Stevenson",
+			want: "STFNSN",
+		},
+		{
+			name: "test Catherine",
+			arg:  "Catherine",
+			want: "KTRN11",
+		},
+		{
+			name: "test Kathryn",
+			arg:  "Kathryn",
+			want: "KTRN11",
+		},
+		{
+			name: "test Whytehead",
+			arg:  "Whytehead",
+			want: "WTT111",
+		},
+		{
+			name: "test no letters",
+			arg:  "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCaverphone1(tt.arg).Key(); got != tt.want {
+				t.Errorf("TestCaverphone1 = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaverphone2(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{
+			name: "test Peter",
+			arg:  "Peter",
+			want: "PTA1111111",
+		},
+		{
+			name: "test ready",
+			arg:  "ready",
+			want: "RTA1111111",
+		},
+		{
+			name: "test social",
+			arg:  "social",
+			want: "SSA1111111",
+		},
+		{
+			name: "test able",
+			arg:  "able",
+			want: "APA1111111",
+		},
+		{
+			name: "test Tedder",
+			arg:  "Tedder",
+			want: "TTA1111111",
+		},
+		{
+			name: "test Karleen",
+			arg:  "Karleen",
+			want: "KLN1111111",
+		},
+		{
+			name: "test Dyun",
+			arg:  "Dyun",
+			want: "TN11111111",
+		},
+		{
+			name: "test Stevenson",
+			arg:  "Stevenson",
+			want: "STFNSN1111",
+		},
+		{
+			name: "test Mackenzie",
+			arg:  "Mackenzie",
+			want: "MKNSA11111",
+		},
+		{
+			name: "test McKenzie",
+			arg:  "McKenzie",
+			want: "MKNSA11111",
+		},
+		{
+			name: "test no letters",
+			arg:  "1234",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp := NewCaverphone2(tt.arg)
+			if got := cp.Key(); got != tt.want {
+				t.Errorf("TestCaverphone2 = %s, want %s", got, tt.want)
+			}
+			if cp.Word() != tt.arg {
+				t.Errorf("TestCaverphone2 Word = %s, want %s", cp.Word(), tt.arg)
+			}
+		})
+	}
+}
+
+func TestCaverphoneEncoder(t *testing.T) {
+	idx := NewPhoneticIndexEncoder[int](CaverphoneEncoder{})
+	idx.Add(1, "Mackenzie")
+	idx.Add(2, "Stevenson")
+	if got := idx.Lookup("McKenzie"); len(got) != 1 || got[0].ID != 1 || got[0].Level != MATCH_STRONG {
+		t.Errorf("TestCaverphoneEncoder Lookup = %v, want [{1 strong}]", got)
+	}
+
+	tests := []struct {
+		name    string
+		encoder CaverphoneEncoder
+		arg     string
+		want    []string
+	}{
+		{
+			name:    "test version 1",
+			encoder: CaverphoneEncoder{Version1: true},
+			arg:     "Stevenson",
+			want:    []string{"STFNSN"},
+		},
+		{
+			name:    "test version 2",
+			encoder: CaverphoneEncoder{},
+			arg:     "Stevenson",
+			want:    []string{"STFNSN1111"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.encoder.Keys(tt.arg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestCaverphoneEncoder = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
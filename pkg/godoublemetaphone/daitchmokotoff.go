@@ -0,0 +1,254 @@
+package godoublemetaphone
+
+import (
+	"sort"
+	"strings"
+)
+
+/**
+ * daitchmokotoff.go
+ *
+ * Daitch-Mokotoff Soundex, designed by Gary Mokotoff and Randy Daitch for Eastern European
+ * Jewish surnames.  A name is coded as six digits; letter groups with more than one
+ * possible pronunciation (e.g. 'CH' as in 'Chaim' or 'Chelm', 'CK' as in 'Jackowski')
+ * branch, so a name yields every code it can produce.
+ *
+ * The rule table and branching follow the Apache Commons Codec implementation.  Accented
+ * letters are folded to their base letters before coding.
+ */
+
+const (
+	DAITCH_MOKOTOFF_KEY_LENGTH = 6 //Length of a Daitch-Mokotoff code
+
+	///Folding applied by NewDaitchMokotoff, the rules only know the letters A-Z
+	DAITCH_MOKOTOFF_FOLDING = FOLD_COMPOSE | FOLD_LIGATURES | FOLD_STRIP_DIACRITICS
+)
+
+type DaitchMokotoff interface {
+	Keys() []string
+	Word() string
+}
+
+type daitchMokotoff struct {
+	keys         []string
+	originalWord string
+}
+
+/// A coding rule: the letter group and its codes at the start of the word, before a vowel
+/// and elsewhere.  Each code lists its alternatives; an empty alternative is not coded
+type daitchMokotoffRule struct {
+	pattern     string
+	atStart     []string
+	beforeVowel []string
+	other       []string
+}
+
+/// Letter group, code at start, code before a vowel, code elsewhere; '|' separates alternatives
+var daitchMokotoffTable = [][4]string{
+	//vowels
+	{"A", "0", "", ""}, {"AI", "0", "1", ""}, {"AJ", "0", "1", ""}, {"AY", "0", "1", ""}, {"AU", "0", "7", ""},
+	{"E", "0", "", ""}, {"EI", "0", "1", ""}, {"EJ", "0", "1", ""}, {"EY", "0", "1", ""}, {"EU", "1", "1", ""},
+	{"I", "0", "", ""}, {"IA", "1", "", ""}, {"IE", "1", "", ""}, {"IO", "1", "", ""}, {"IU", "1", "", ""},
+	{"O", "0", "", ""}, {"OI", "0", "1", ""}, {"OJ", "0", "1", ""}, {"OY", "0", "1", ""},
+	{"U", "0", "", ""}, {"UE", "0", "", ""}, {"UI", "0", "1", ""}, {"UJ", "0", "1", ""}, {"UY", "0", "1", ""},
+	{"Y", "1", "", ""},
+
+	//consonants
+	{"B", "7", "7", "7"},
+	{"C", "5|4", "5|4", "5|4"}, {"CH", "5|4", "5|4", "5|4"}, {"CHS", "5", "54", "54"}, {"CK", "5|45", "5|45", "5|45"},
+	{"CS", "4", "4", "4"}, {"CSZ", "4", "4", "4"}, {"CZ", "4", "4", "4"}, {"CZS", "4", "4", "4"},
+	{"D", "3", "3", "3"}, {"DRS", "4", "4", "4"}, {"DRZ", "4", "4", "4"}, {"DS", "4", "4", "4"}, {"DSH", "4", "4", "4"},
+	{"DSZ", "4", "4", "4"}, {"DT", "3", "3", "3"}, {"DZ", "4", "4", "4"}, {"DZH", "4", "4", "4"}, {"DZS", "4", "4", "4"},
+	{"F", "7", "7", "7"}, {"FB", "7", "7", "7"},
+	{"G", "5", "5", "5"},
+	{"H", "5", "5", ""},
+	{"J", "1|4", "|4", "|4"},
+	{"K", "5", "5", "5"}, {"KH", "5", "5", "5"}, {"KS", "5", "54", "54"},
+	{"L", "8", "8", "8"},
+	{"M", "6", "6", "6"}, {"MN", "66", "66", "66"},
+	{"N", "6", "6", "6"}, {"NM", "66", "66", "66"},
+	{"P", "7", "7", "7"}, {"PF", "7", "7", "7"}, {"PH", "7", "7", "7"},
+	{"Q", "5", "5", "5"},
+	{"R", "9", "9", "9"}, {"RS", "94|4", "94|4", "94|4"}, {"RZ", "94|4", "94|4", "94|4"},
+	{"S", "4", "4", "4"}, {"SCH", "4", "4", "4"}, {"SCHTCH", "2", "4", "4"}, {"SCHTSCH", "2", "4", "4"},
+	{"SCHTSH", "2", "4", "4"}, {"SD", "2", "43", "43"}, {"SH", "4", "4", "4"}, {"SHCH", "2", "4", "4"},
+	{"SHD", "2", "43", "43"}, {"SHT", "2", "43", "43"}, {"SHTCH", "2", "4", "4"}, {"SHTSH", "2", "4", "4"},
+	{"ST", "2", "43", "43"}, {"STCH", "2", "4", "4"}, {"STRS", "2", "4", "4"}, {"STRZ", "2", "4", "4"},
+	{"STSCH", "2", "4", "4"}, {"STSH", "2", "4", "4"}, {"STZ", "2", "4", "4"}, {"SZ", "4", "4", "4"},
+	{"SZCS", "2", "4", "4"}, {"SZCZ", "2", "4", "4"}, {"SZD", "2", "43", "43"}, {"SZT", "2", "43", "43"},
+	{"T", "3", "3", "3"}, {"TC", "4", "4", "4"}, {"TCH", "4", "4", "4"}, {"TH", "3", "3", "3"}, {"TRCH", "4", "4", "4"},
+	{"TRS", "4", "4", "4"}, {"TRZ", "4", "4", "4"}, {"TS", "4", "4", "4"}, {"TSCH", "4", "4", "4"}, {"TSH", "4", "4", "4"},
+	{"TSZ", "4", "4", "4"}, {"TTCH", "4", "4", "4"}, {"TTSCH", "4", "4", "4"}, {"TTSZ", "4", "4", "4"}, {"TTZ", "4", "4", "4"},
+	{"TZ", "4", "4", "4"}, {"TZS", "4", "4", "4"},
+	{"V", "7", "7", "7"},
+	{"W", "7", "7", "7"},
+	{"X", "5", "54", "54"},
+	{"Z", "4", "4", "4"}, {"ZD", "2", "43", "43"}, {"ZDZ", "2", "4", "4"}, {"ZDZH", "2", "4", "4"}, {"ZH", "4", "4", "4"},
+	{"ZHD", "2", "43", "43"}, {"ZHDZH", "2", "4", "4"}, {"ZS", "4", "4", "4"}, {"ZSCH", "4", "4", "4"}, {"ZSH", "4", "4", "4"},
+}
+
+/// Rules by first letter, longest letter group first
+var daitchMokotoffRules = buildDaitchMokotoffRules()
+
+func buildDaitchMokotoffRules() map[byte][]daitchMokotoffRule {
+	rules := map[byte][]daitchMokotoffRule{}
+	for _, entry := range daitchMokotoffTable {
+		rule := daitchMokotoffRule{
+			pattern:     entry[0],
+			atStart:     strings.Split(entry[1], "|"),
+			beforeVowel: strings.Split(entry[2], "|"),
+			other:       strings.Split(entry[3], "|"),
+		}
+		rules[rule.pattern[0]] = append(rules[rule.pattern[0]], rule)
+	}
+
+	for _, group := range rules {
+		sort.SliceStable(group, func(i, j int) bool {
+			return len(group[i].pattern) > len(group[j].pattern)
+		})
+	}
+
+	return rules
+}
+
+/// A code being built for one of the pronunciations of the word
+type daitchMokotoffBranch struct {
+	code []byte
+	///Last code applied, a group is not coded again if the previous group ended with its code
+	last string
+}
+
+func NewDaitchMokotoff(word string) DaitchMokotoff {
+	return newDaitchMokotoff(word, DAITCH_MOKOTOFF_FOLDING)
+}
+
+/// <summary>Computes the Daitch-Mokotoff codes after applying the given Unicode folding (a
+///     combination of FOLD_* flags) to the word instead of DAITCH_MOKOTOFF_FOLDING</summary>
+func NewDaitchMokotoffFolding(word string, folding Folding) DaitchMokotoff {
+	return newDaitchMokotoff(word, folding)
+}
+
+func newDaitchMokotoff(word string, folding Folding) *daitchMokotoff {
+	runes, length := prepareWord(nil, word, folding)
+
+	letters := make([]byte, 0, length)
+	for _, r := range runes[:length] {
+		if r >= 'A' && r <= 'Z' {
+			letters = append(letters, byte(r))
+		}
+	}
+
+	return &daitchMokotoff{
+		keys:         daitchMokotoffKeys(string(letters)),
+		originalWord: word,
+	}
+}
+
+/// <summary>Every six digit code the word can produce, in the order the branches were
+///     taken; the first is the code taking the first alternative of every letter group.
+///     Empty if the word has no letters</summary>
+func (dmk *daitchMokotoff) Keys() []string {
+	return dmk.keys
+}
+
+/// <summary>Original word for which the codes were computed</summary>
+func (dmk *daitchMokotoff) Word() string {
+	return dmk.originalWord
+}
+
+/// DaitchMokotoffEncoder is the PhoneticEncoder for Daitch-Mokotoff Soundex; the first code
+/// of a word is its primary key, the other branches are alternates.  The zero value folds
+/// with DAITCH_MOKOTOFF_FOLDING
+type DaitchMokotoffEncoder struct {
+	Folding Folding
+}
+
+func (enc DaitchMokotoffEncoder) Keys(word string) []string {
+	folding := enc.Folding
+	if folding == FOLD_NONE {
+		folding = DAITCH_MOKOTOFF_FOLDING
+	}
+
+	return newDaitchMokotoff(word, folding).keys
+}
+
+/**
+* Internal impl of Daitch-Mokotoff Soundex on the upper case letters of the word
+ */
+func daitchMokotoffKeys(letters string) []string {
+	if len(letters) == 0 {
+		return []string{}
+	}
+
+	branches := []*daitchMokotoffBranch{{}}
+	var lastLetter byte
+	for current := 0; current < len(letters); current++ {
+		letter := letters[current]
+		for _, rule := range daitchMokotoffRules[letter] {
+			if !strings.HasPrefix(letters[current:], rule.pattern) {
+				continue
+			}
+
+			var codes []string
+			next := current + len(rule.pattern)
+			switch {
+			case current == 0:
+				codes = rule.atStart
+			case next < len(letters) && strings.IndexByte("AEIOU", letters[next]) >= 0:
+				codes = rule.beforeVowel
+			default:
+				codes = rule.other
+			}
+
+			//adjacent M and N are both coded
+			force := lastLetter == 'M' && letter == 'N' || lastLetter == 'N' && letter == 'M'
+
+			branches = nextDaitchMokotoffBranches(branches, codes, force)
+			current = next - 1
+			break
+		}
+		lastLetter = letter
+	}
+
+	keys := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		for len(branch.code) < DAITCH_MOKOTOFF_KEY_LENGTH {
+			branch.code = append(branch.code, '0')
+		}
+		keys = append(keys, string(branch.code))
+	}
+
+	return keys
+}
+
+/// <summary>Applies the alternative codes of a letter group to every branch, splitting
+///     branches when there is more than one.  A code is not repeated if the previous group
+///     of the branch ended with it unless force is set.  Branches that end up with the same
+///     code are merged</summary>
+func nextDaitchMokotoffBranches(branches []*daitchMokotoffBranch, codes []string, force bool) []*daitchMokotoffBranch {
+	next := make([]*daitchMokotoffBranch, 0, len(branches)*len(codes))
+	seen := map[string]bool{}
+	for _, branch := range branches {
+		for _, code := range codes {
+			nextBranch := branch
+			if len(codes) > 1 {
+				nextBranch = &daitchMokotoffBranch{code: append([]byte(nil), branch.code...), last: branch.last}
+			}
+
+			if (force || !strings.HasSuffix(nextBranch.last, code)) && len(nextBranch.code) < DAITCH_MOKOTOFF_KEY_LENGTH {
+				nextBranch.code = append(nextBranch.code, code...)
+				if len(nextBranch.code) > DAITCH_MOKOTOFF_KEY_LENGTH {
+					nextBranch.code = nextBranch.code[:DAITCH_MOKOTOFF_KEY_LENGTH]
+				}
+			}
+			nextBranch.last = code
+
+			if !seen[string(nextBranch.code)] {
+				seen[string(nextBranch.code)] = true
+				next = append(next, nextBranch)
+			}
+		}
+	}
+
+	return next
+}
@@ -0,0 +1,139 @@
+package godoublemetaphone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDaitchMokotoff(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want []string
+	}{
+		{
+			name: "test Auerbach CH branches",
+			arg:  "Auerbach",
+			want: []string{"097500", "097400"},
+		},
+		{
+			name: "test Ohrbach",
+			arg:  "Ohrbach",
+			want: []string{"097500", "097400"},
+		},
+		{
+			name: "test Lipshitz",
+			arg:  "Lipshitz",
+			want: []string{"874400"},
+		},
+		{
+			name: "test Lippszyc",
+			arg:  "Lippszyc",
+			want: []string{"874500", "874400"},
+		},
+		{
+			name: "test Lewinsky",
+			arg:  "Lewinsky",
+			want: []string{"876450"},
+		},
+		{
+			name: "test Levinski",
+			arg:  "Levinski",
+			want: []string{"876450"},
+		},
+		{
+			name: "test Szlamawicz",
+			arg:  "Szlamawicz",
+			want: []string{"486740"},
+		},
+		{
+			name: "test Shlamovitz",
+			arg:  "Shlamovitz",
+			want: []string{"486740"},
+		},
+		{
+			name: "test Moskowitz",
+			arg:  "Moskowitz",
+			want: []string{"645740"},
+		},
+		{
+			name: "test Peters RS branches",
+			arg:  "Peters",
+			want: []string{"739400", "734000"},
+		},
+		{
+			name: "test Jackson J and CK branches",
+			arg:  "Jackson",
+			want: []string{"154600", "145460", "454600", "445460"},
+		},
+		{
+			name: "test Chaim",
+			arg:  "Chaim",
+			want: []string{"560000", "460000"},
+		},
+		{
+			name: "test Kleinman MN",
+			arg:  "Kleinman",
+			want: []string{"586660"},
+		},
+		{
+			name: "test Rosochowaciec",
+			arg:  "Rosochowaciec",
+			want: []string{"945755", "945754", "945745", "945744", "944755", "944754", "944745", "944744"},
+		},
+		{
+			name: "test Müller folded",
+			arg:  "Müller",
+			want: []string{"689000"},
+		},
+		{
+			name: "test lower case and punctuation",
+			arg:  "o'shea",
+			want: []string{"040000"},
+		},
+		{
+			name: "test no letters",
+			arg:  "42",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dmk := NewDaitchMokotoff(tt.arg)
+			if got := dmk.Keys(); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("TestDaitchMokotoff = %v, want %v", got, tt.want)
+			}
+			if dmk.Word() != tt.arg {
+				t.Errorf("TestDaitchMokotoff Word = %s, want %s", dmk.Word(), tt.arg)
+			}
+		})
+	}
+}
+
+func TestDaitchMokotoffEncoder(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want MatchLevel
+	}{
+		{
+			name: "test Auerbach Ohrbach",
+			a:    "Auerbach",
+			b:    "Ohrbach",
+			want: MATCH_STRONG,
+		},
+		{
+			name: "test Peters Petes",
+			a:    "Peters",
+			b:    "Petes",
+			want: MATCH_NORMAL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareWith(DaitchMokotoffEncoder{}, tt.a, tt.b); got != tt.want {
+				t.Errorf("TestDaitchMokotoffEncoder = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
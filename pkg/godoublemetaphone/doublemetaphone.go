@@ -1,9 +1,7 @@
 package godoublemetaphone
 
 import (
-	"fmt"
-	"math"
-	"strings"
+	"unicode"
 )
 
 /**
@@ -21,6 +19,10 @@ import (
  *
  */
 
+const (
+	wordPadding = 5 //Number of spaces appended to a working copy of a word
+)
+
 type DoubleMetaphone interface {
 	PrimaryKey() string
 	AlternateKey() *string
@@ -30,9 +32,12 @@ type DoubleMetaphone interface {
 type doubleMetaphone struct {
 	maxKeyLength int
 
+	///Unicode folding applied to the word before the keys are computed
+	folding Folding
+
 	///StringBuilders used to construct the keys
-	primaryKey   []rune
-	alternateKey []rune
+	primaryKey   []byte
+	alternateKey []byte
 
 	///Actual keys, populated after construction
 	primaryKeyString   string
@@ -42,31 +47,60 @@ type doubleMetaphone struct {
 	primaryKeyLength   int
 	alternateKeyLength int
 
-	///Working copy of the word, and the original word
-	word         string
+	///Working copy of the word as runes, and the original word
+	word         []rune
 	originalWord string
 
-	///Length and last valid zero-based index into word
+	///Length (in runes) and last valid zero-based index into word
 	length int
 	last   int
 
+	///Flag indicating if the word is considered slavo-germanic, computed once per word
+	slavoGermanic bool
+
 	///Flag indicating if an alternate metaphone key was computed for the word
 	hasAlternate bool
+
+	///Name of the rule applied at the current position, and the steps recorded when explaining
+	rule    string
+	explain bool
+	steps   []Step
 }
 
 func NewDoubleMetaphone(word string) DoubleMetaphone {
-	return newDoubleMetaphone(word, math.MaxInt64)
+	return New(word)
 }
 
+/// <summary>Computes the metaphone keys, limited to maxKeyLength.  Unlike WithMaxKeyLength,
+///     a limit of zero or less gives empty keys</summary>
 func NewDoubleMetaphoneLimit(word string, maxKeyLength int) DoubleMetaphone {
-	return newDoubleMetaphone(word, maxKeyLength)
+	return newDoubleMetaphone(word, maxKeyLength, FOLD_NONE)
+}
+
+/// <summary>Computes the metaphone keys after applying the given Unicode folding (a
+///     combination of FOLD_* flags) to the word</summary>
+func NewDoubleMetaphoneFolding(word string, folding Folding) DoubleMetaphone {
+	return New(word, WithFolding(folding))
 }
 
-func newDoubleMetaphone(word string, maxKeyLength int) *doubleMetaphone {
+/// <summary>Computes the metaphone keys, limited to maxKeyLength, after applying the given
+///     Unicode folding (a combination of FOLD_* flags) to the word.  A limit of zero or less
+///     gives empty keys, as for NewDoubleMetaphoneLimit</summary>
+func NewDoubleMetaphoneLimitFolding(word string, maxKeyLength int, folding Folding) DoubleMetaphone {
+	return newDoubleMetaphone(word, maxKeyLength, folding)
+}
+
+func newDoubleMetaphone(word string, maxKeyLength int, folding Folding) *doubleMetaphone {
+	//a negative limit would slice the keys out of range
+	if maxKeyLength < 0 {
+		maxKeyLength = 0
+	}
+
 	dm := &doubleMetaphone{
 		maxKeyLength: maxKeyLength,
-		primaryKey:   []rune{},
-		alternateKey: []rune{},
+		folding:      folding,
+		primaryKey:   []byte{},
+		alternateKey: []byte{},
 	}
 
 	dm.computeKeys(word)
@@ -118,8 +152,25 @@ static public void doubleMetaphone(String word, ref String primaryKey, ref Strin
 /// <param name="word">New word to set to current word.  Discards previous metaphone keys,
 ///     and computes new keys for this word</param>
 func (dm *doubleMetaphone) computeKeys(word string) {
-	dm.primaryKey = []rune{}
-	dm.alternateKey = []rune{}
+	dm.computeKeyBuffers(word)
+
+	dm.primaryKeyString = string(dm.primaryKey)
+	dm.alternateKeyString = string(dm.alternateKey)
+}
+
+/// <summary>Computes the metaphone keys for word into dm.primaryKey and dm.alternateKey
+///     without converting them to strings.  The buffers of a previous word are reused, so
+///     this does not allocate once they have grown large enough</summary>
+func (dm *doubleMetaphone) computeKeyBuffers(word string) {
+	//Size the buffers up front, so they grow once rather than on every append.  Each letter
+	//adds at most two characters to a key
+	if cap(dm.primaryKey) < 2*len(word) {
+		dm.primaryKey = make([]byte, 0, 2*len(word))
+		dm.alternateKey = make([]byte, 0, 2*len(word))
+	}
+
+	dm.primaryKey = dm.primaryKey[:0]
+	dm.alternateKey = dm.alternateKey[:0]
 
 	dm.primaryKeyString = ""
 	dm.alternateKeyString = ""
@@ -131,24 +182,56 @@ func (dm *doubleMetaphone) computeKeys(word string) {
 
 	dm.originalWord = word
 
-	//Copy word to an internal working buffer so it can be modified
-	dm.word = word
-
-	dm.length = len(dm.word)
+	dm.word, dm.length = prepareWord(dm.word, word, dm.folding)
 
 	//Compute last valid index into word
 	dm.last = dm.length - 1
 
-	//Padd with four spaces, so word can be over-indexed without fear of exception
-	dm.word = fmt.Sprintf("%s%s", dm.word, strings.Repeat(" ", 5))
-
-	//Convert to upper case, since metaphone is not case sensitive
-	dm.word = strings.ToUpper(dm.word)
+	dm.slavoGermanic = false
+	for idx := 0; idx < dm.length; idx++ {
+		//'WITZ' is covered by 'W'
+		r := dm.word[idx]
+		if r == 'W' || r == 'K' || (r == 'Z' && idx > 0 && dm.word[idx-1] == 'C') {
+			dm.slavoGermanic = true
+			break
+		}
+	}
 
 	//Now build the keys
 	dm.buildMetaphoneKeys()
 }
 
+/// <summary>Folds and upper cases word into a working buffer of runes, so multi-byte letters
+///     are a single position, padded with spaces so it can be over-indexed without fear of
+///     exception.  Shared by the phonetic encoders of this package</summary>
+///
+/// <param name="buffer">Buffer to reuse if it is large enough, may be nil</param>
+/// <param name="word">Word to prepare</param>
+/// <param name="folding">Unicode folding to apply</param>
+///
+/// <returns>The padded buffer, and the length of the word in runes without the padding</returns>
+func prepareWord(buffer []rune, word string, folding Folding) ([]rune, int) {
+	folded := FoldWord(word, folding)
+
+	//a word has no more runes than bytes
+	if cap(buffer) < len(folded)+wordPadding {
+		buffer = make([]rune, 0, len(folded)+wordPadding)
+	}
+
+	//Convert to upper case, since metaphone is not case sensitive
+	buffer = buffer[:0]
+	for _, r := range folded {
+		buffer = append(buffer, unicode.ToUpper(r))
+	}
+	length := len(buffer)
+
+	for idx := 0; idx < wordPadding; idx++ {
+		buffer = append(buffer, ' ')
+	}
+
+	return buffer, length
+}
+
 /**
 * Internal impl of double metaphone algorithm.  Populates dm.primaryKey and dm.alternateKey.  Modified copy-past of
 * Phillips' original code
@@ -161,13 +244,17 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 
 	//skip these when at start of word
 	if dm.areStringsAt(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		dm.rule = "initial GN, KN, PN, WR, PS"
 		current += 1
+		dm.recordStep(0, current, 0, 0)
 	}
 
 	//Initial 'X' is pronounced 'Z' e.g. 'Xavier'
 	if dm.word[0] == 'X' {
+		dm.rule = "initial X"
 		dm.addMetaphoneCharacter("S") //'Z' maps to 'S'
 		current += 1
+		dm.recordStep(0, current, 0, 0)
 	}
 
 	///////////main loop//////////////////////////
@@ -176,6 +263,9 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			break
 		}
 
+		start, primaryStart, alternateStart := current, len(dm.primaryKey), len(dm.alternateKey)
+		dm.rule = ""
+
 		switch dm.word[current] {
 		case 'A':
 			fallthrough
@@ -188,6 +278,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 		case 'U':
 			fallthrough
 		case 'Y':
+			dm.rule = "vowel"
 			if current == 0 {
 				//all init vowels now map to 'A'
 				dm.addMetaphoneCharacter("A")
@@ -196,6 +287,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 
 		case 'B':
 			//"-mb", e.g", "dumb", already skipped over...
+			dm.rule = "B"
 			dm.addMetaphoneCharacter("P")
 
 			if dm.word[current+1] == 'B' {
@@ -205,6 +297,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			}
 
 		case 'Ç':
+			dm.rule = "Ç"
 			dm.addMetaphoneCharacter("S")
 			current += 1
 
@@ -215,6 +308,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 				dm.areStringsAt((current-1), 3, "ACH") &&
 				((dm.word[current+2] != 'I') && ((dm.word[current+2] != 'E') ||
 					dm.areStringsAt((current-2), 6, "BACHER", "MACHER"))) {
+				dm.rule = "germanic ACH"
 				dm.addMetaphoneCharacter("K")
 				current += 2
 				break
@@ -222,6 +316,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 
 			//special case 'caesar'
 			if (current == 0) && dm.areStringsAt(current, 6, "CAESAR") {
+				dm.rule = "caesar"
 				dm.addMetaphoneCharacter("S")
 				current += 2
 				break
@@ -229,6 +324,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 
 			//italian 'chianti'
 			if dm.areStringsAt(current, 4, "CHIA") {
+				dm.rule = "italian CHIA"
 				dm.addMetaphoneCharacter("K")
 				current += 2
 				break
@@ -237,6 +333,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			if dm.areStringsAt(current, 2, "CH") {
 				//find 'michael'
 				if (current > 0) && dm.areStringsAt(current, 4, "CHAE") {
+					dm.rule = "michael CHAE"
 					dm.addMetaphoneCharacters("K", "X")
 					current += 2
 					break
@@ -247,6 +344,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 					(dm.areStringsAt((current+1), 5, "HARAC", "HARIS") ||
 						dm.areStringsAt((current+1), 3, "HOR", "HYM", "HIA", "HEM")) &&
 					!dm.areStringsAt(0, 5, "CHORE") {
+					dm.rule = "greek CH"
 					dm.addMetaphoneCharacter("K")
 					current += 2
 					break
@@ -260,16 +358,20 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 					((dm.areStringsAt((current-1), 1, "A", "O", "U", "E") || (current == 0)) &&
 						//e.g., 'wachtler', 'wechsler', but not 'tichner'
 						dm.areStringsAt((current+2), 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ")) {
+					dm.rule = "germanic CH"
 					dm.addMetaphoneCharacter("K")
 				} else {
 					if current > 0 {
 						if dm.areStringsAt(0, 2, "MC") {
 							//e.g., "McHugh"
+							dm.rule = "McHugh CH"
 							dm.addMetaphoneCharacter("K")
 						} else {
+							dm.rule = "CH"
 							dm.addMetaphoneCharacters("X", "K")
 						}
 					} else {
+						dm.rule = "initial CH"
 						dm.addMetaphoneCharacter("X")
 					}
 				}
@@ -278,6 +380,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			}
 			//e.g, 'czerny'
 			if dm.areStringsAt(current, 2, "CZ") && !dm.areStringsAt((current-2), 4, "WICZ") {
+				dm.rule = "polish CZ"
 				dm.addMetaphoneCharacters("S", "X")
 				current += 2
 				break
@@ -285,6 +388,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 
 			//e.g., 'focaccia'
 			if dm.areStringsAt((current + 1), 3, "CIA") {
+				dm.rule = "italian CIA"
 				dm.addMetaphoneCharacter("X")
 				current += 3
 				break
@@ -297,14 +401,17 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 					//'accident', 'accede' 'succeed'
 					if ((current == 1) && (dm.word[current-1] == 'A')) ||
 						dm.areStringsAt((current-1), 5, "UCCEE", "UCCES") {
+						dm.rule = "accident CC"
 						dm.addMetaphoneCharacter("KS")
 						//'bacci', 'bertucci', other italian
 					} else {
+						dm.rule = "italian CC"
 						dm.addMetaphoneCharacter("X")
 					}
 					current += 3
 					break
 				} else { //Pierce's rule
+					dm.rule = "Pierce's rule"
 					dm.addMetaphoneCharacter("K")
 					current += 2
 					break
@@ -312,6 +419,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			}
 
 			if dm.areStringsAt(current, 2, "CK", "CG", "CQ") {
+				dm.rule = "CK, CG, CQ"
 				dm.addMetaphoneCharacter("K")
 				current += 2
 				break
@@ -320,8 +428,10 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			if dm.areStringsAt(current, 2, "CI", "CE", "CY") {
 				//italian vs. english
 				if dm.areStringsAt(current, 3, "CIO", "CIE", "CIA") {
+					dm.rule = "italian CI"
 					dm.addMetaphoneCharacters("S", "X")
 				} else {
+					dm.rule = "soft C"
 					dm.addMetaphoneCharacter("S")
 				}
 				current += 2
@@ -329,6 +439,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			}
 
 			//else
+			dm.rule = "hard C"
 			dm.addMetaphoneCharacter("K")
 
 			//name sent in 'mac caffrey', 'mac gregor
@@ -347,11 +458,13 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			if dm.areStringsAt(current, 2, "DG") {
 				if dm.areStringsAt((current + 2), 1, "I", "E", "Y") {
 					//e.g. 'edge'
+					dm.rule = "DGE"
 					dm.addMetaphoneCharacter("J")
 					current += 3
 					break
 				} else {
 					//e.g. 'edgar'
+					dm.rule = "DG"
 					dm.addMetaphoneCharacter("TK")
 					current += 2
 					break
@@ -359,16 +472,19 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			}
 
 			if dm.areStringsAt(current, 2, "DT", "DD") {
+				dm.rule = "DT, DD"
 				dm.addMetaphoneCharacter("T")
 				current += 2
 				break
 			}
 
 			//else
+			dm.rule = "D"
 			dm.addMetaphoneCharacter("T")
 			current += 1
 
 		case 'F':
+			dm.rule = "F"
 			if dm.word[current+1] == 'F' {
 				current += 2
 			} else {
@@ -379,6 +495,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 		case 'G':
 			if dm.word[current+1] == 'H' {
 				if (current > 0) && !dm.isVowel(current-1) {
+					dm.rule = "GH after consonant"
 					dm.addMetaphoneCharacter("K")
 					current += 2
 					break
@@ -389,8 +506,10 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 					if current == 0 {
 						if dm.word[current+2] == 'I' {
 
+							dm.rule = "initial GHI"
 							dm.addMetaphoneCharacter("J")
 						} else {
+							dm.rule = "initial GH"
 							dm.addMetaphoneCharacter("K")
 						}
 						current += 2
@@ -403,6 +522,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 					((current > 2) && dm.areStringsAt((current-3), 1, "B", "H", "D")) ||
 					//e.g., 'broughton'
 					((current > 3) && dm.areStringsAt((current-4), 1, "B", "H")) {
+					dm.rule = "Parker's rule"
 					current += 2
 					break
 				} else {
@@ -410,8 +530,10 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 					if (current > 2) &&
 						(dm.word[current-1] == 'U') &&
 						dm.areStringsAt((current-3), 1, "C", "G", "L", "R", "T") {
+						dm.rule = "GH as F"
 						dm.addMetaphoneCharacter("F")
 					} else {
+						dm.rule = "GH"
 						if (current > 0) && dm.word[current-1] != 'I' {
 							dm.addMetaphoneCharacter("K")
 						}
@@ -424,13 +546,16 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 
 			if dm.word[current+1] == 'N' {
 				if (current == 1) && dm.isVowel(0) && !dm.isWordSlavoGermanic() {
+					dm.rule = "initial vowel GN"
 					dm.addMetaphoneCharacters("KN", "N")
 				} else {
 					//not e.g. 'cagney'
 					if !dm.areStringsAt((current+2), 2, "EY") &&
 						(dm.word[current+1] != 'Y') && !dm.isWordSlavoGermanic() {
+						dm.rule = "GN"
 						dm.addMetaphoneCharacters("N", "KN")
 					} else {
+						dm.rule = "KN"
 						dm.addMetaphoneCharacter("KN")
 					}
 				}
@@ -440,6 +565,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 
 			//'tagliaro'
 			if dm.areStringsAt((current+1), 2, "LI") && !dm.isWordSlavoGermanic() {
+				dm.rule = "italian GLI"
 				dm.addMetaphoneCharacters("KL", "L")
 				current += 2
 				break
@@ -449,6 +575,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			if (current == 0) &&
 				((dm.word[current+1] == 'Y') ||
 					dm.areStringsAt((current+1), 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")) {
+				dm.rule = "initial GE, GI, GY"
 				dm.addMetaphoneCharacters("K", "J")
 				current += 2
 				break
@@ -459,6 +586,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 				!dm.areStringsAt(0, 6, "DANGER", "RANGER", "MANGER") &&
 				!dm.areStringsAt((current-1), 1, "E", "I") &&
 				!dm.areStringsAt((current-1), 3, "RGY", "OGY") {
+				dm.rule = "GER, GY"
 				dm.addMetaphoneCharacters("K", "J")
 				current += 2
 				break
@@ -469,12 +597,15 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 				//obvious germanic
 				if (dm.areStringsAt(0, 4, "VAN ", "VON ") || dm.areStringsAt(0, 3, "SCH")) ||
 					dm.areStringsAt((current+1), 2, "ET") {
+					dm.rule = "germanic G"
 					dm.addMetaphoneCharacter("K")
 				} else {
 					//always soft if french ending
 					if dm.areStringsAt((current + 1), 4, "IER ") {
+						dm.rule = "french GIER"
 						dm.addMetaphoneCharacter("J")
 					} else {
+						dm.rule = "italian G"
 						dm.addMetaphoneCharacters("J", "K")
 					}
 				}
@@ -482,6 +613,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 				break
 			}
 
+			dm.rule = "G"
 			if dm.word[current+1] == 'G' {
 				current += 2
 			} else {
@@ -493,9 +625,11 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			//only keep if first & before vowel or btw. 2 vowels
 			if ((current == 0) || dm.isVowel(current-1)) &&
 				dm.isVowel(current+1) {
+				dm.rule = "H between vowels"
 				dm.addMetaphoneCharacter("H")
 				current += 2
 			} else { //also takes care of 'HH'
+				dm.rule = "silent H"
 				current += 1
 			}
 
@@ -503,8 +637,10 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			//obvious spanish, 'jose', 'san jacinto'
 			if dm.areStringsAt(current, 4, "JOSE") || dm.areStringsAt(0, 4, "SAN ") {
 				if ((current == 0) && (dm.word[current+4] == ' ')) || dm.areStringsAt(0, 4, "SAN ") {
+					dm.rule = "spanish JOSE, SAN"
 					dm.addMetaphoneCharacter("H")
 				} else {
+					dm.rule = "JOSE inside word"
 					dm.addMetaphoneCharacters("J", "H")
 				}
 				current += 1
@@ -512,19 +648,23 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			}
 
 			if (current == 0) && !dm.areStringsAt(current, 4, "JOSE") {
+				dm.rule = "initial J"
 				dm.addMetaphoneCharacters("J", "A") //Yankelovich/Jankelowicz
 			} else {
 				//spanish pron. of e.g. 'bajador'
 				if dm.isVowel(current-1) &&
 					!dm.isWordSlavoGermanic() &&
 					((dm.word[current+1] == 'A') || (dm.word[current+1] == 'O')) {
+					dm.rule = "spanish J"
 					dm.addMetaphoneCharacters("J", "H")
 				} else {
 					if current == dm.last {
+						dm.rule = "final J"
 						dm.addMetaphoneCharacters("J", " ")
 					} else {
 						if !dm.areStringsAt((current+1), 1, "L", "T", "K", "S", "N", "M", "B", "Z") &&
 							!dm.areStringsAt((current-1), 1, "S", "K", "L") {
+							dm.rule = "J"
 							dm.addMetaphoneCharacter("J")
 						}
 					}
@@ -538,6 +678,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			}
 
 		case 'K':
+			dm.rule = "K"
 			if dm.word[current+1] == 'K' {
 				current += 2
 			} else {
@@ -552,17 +693,21 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 					dm.areStringsAt((current-1), 4, "ILLO", "ILLA", "ALLE")) ||
 					((dm.areStringsAt((dm.last-1), 2, "AS", "OS") || dm.areStringsAt(dm.last, 1, "A", "O")) &&
 						dm.areStringsAt((current-1), 4, "ALLE")) {
+					dm.rule = "spanish LL"
 					dm.addMetaphoneCharacters("L", " ")
 					current += 2
 					break
 				}
+				dm.rule = "LL"
 				current += 2
 			} else {
+				dm.rule = "L"
 				current += 1
 			}
 			dm.addMetaphoneCharacter("L")
 
 		case 'M':
+			dm.rule = "M"
 			if (dm.areStringsAt((current-1), 3, "UMB") &&
 				(((current + 1) == dm.last) || dm.areStringsAt((current+2), 2, "ER"))) ||
 				//'dumb','thumb'
@@ -574,6 +719,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			dm.addMetaphoneCharacter("M")
 
 		case 'N':
+			dm.rule = "N"
 			if dm.word[current+1] == 'N' {
 				current += 2
 			} else {
@@ -582,17 +728,20 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			dm.addMetaphoneCharacter("N")
 
 		case 'Ñ':
+			dm.rule = "Ñ"
 			current += 1
 			dm.addMetaphoneCharacter("N")
 
 		case 'P':
 			if dm.word[current+1] == 'H' {
+				dm.rule = "PH"
 				dm.addMetaphoneCharacter("F")
 				current += 2
 				break
 			}
 
 			//also account for "campbell", "raspberry"
+			dm.rule = "P"
 			if dm.areStringsAt((current + 1), 1, "P", "B") {
 				current += 2
 			} else {
@@ -601,6 +750,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			dm.addMetaphoneCharacter("P")
 
 		case 'Q':
+			dm.rule = "Q"
 			if dm.word[current+1] == 'Q' {
 				current += 2
 			} else {
@@ -614,8 +764,10 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 				!dm.isWordSlavoGermanic() &&
 				dm.areStringsAt((current-2), 2, "IE") &&
 				!dm.areStringsAt((current-4), 2, "ME", "MA") {
+				dm.rule = "french R"
 				dm.addMetaphoneCharacters("", "R")
 			} else {
+				dm.rule = "R"
 				dm.addMetaphoneCharacter("R")
 			}
 
@@ -628,12 +780,14 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 		case 'S':
 			//special cases 'island', 'isle', 'carlisle', 'carlysle'
 			if dm.areStringsAt((current - 1), 3, "ISL", "YSL") {
+				dm.rule = "island S"
 				current += 1
 				break
 			}
 
 			//special case 'sugar-'
 			if (current == 0) && dm.areStringsAt(current, 5, "SUGAR") {
+				dm.rule = "sugar"
 				dm.addMetaphoneCharacters("X", "S")
 				current += 1
 				break
@@ -642,8 +796,10 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			if dm.areStringsAt(current, 2, "SH") {
 				//germanic
 				if dm.areStringsAt((current + 1), 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+					dm.rule = "germanic SH"
 					dm.addMetaphoneCharacter("S")
 				} else {
+					dm.rule = "SH"
 					dm.addMetaphoneCharacter("X")
 				}
 				current += 2
@@ -653,8 +809,10 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			//italian & armenian
 			if dm.areStringsAt(current, 3, "SIO", "SIA") || dm.areStringsAt(current, 4, "SIAN") {
 				if !dm.isWordSlavoGermanic() {
+					dm.rule = "italian SIO, SIA"
 					dm.addMetaphoneCharacters("S", "X")
 				} else {
+					dm.rule = "SIO, SIA"
 					dm.addMetaphoneCharacter("S")
 				}
 				current += 3
@@ -666,6 +824,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			if ((current == 0) &&
 				dm.areStringsAt((current+1), 1, "M", "N", "L", "W")) ||
 				dm.areStringsAt((current+1), 1, "Z") {
+				dm.rule = "germanic S, slavic SZ"
 				dm.addMetaphoneCharacters("S", "X")
 				if dm.areStringsAt((current + 1), 1, "Z") {
 					current += 2
@@ -682,16 +841,20 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 					if dm.areStringsAt((current + 3), 2, "OO", "ER", "EN", "UY", "ED", "EM") {
 						//'schermerhorn', 'schenker'
 						if dm.areStringsAt((current + 3), 2, "ER", "EN") {
+							dm.rule = "Schlesinger's rule, dutch SCHER, SCHEN"
 							dm.addMetaphoneCharacters("X", "SK")
 						} else {
+							dm.rule = "Schlesinger's rule, dutch SCH"
 							dm.addMetaphoneCharacter("SK")
 						}
 						current += 3
 						break
 					} else {
 						if (current == 0) && !dm.isVowel(3) && (dm.word[3] != 'W') {
+							dm.rule = "Schlesinger's rule, initial SCH"
 							dm.addMetaphoneCharacters("X", "S")
 						} else {
+							dm.rule = "Schlesinger's rule"
 							dm.addMetaphoneCharacter("X")
 						}
 						current += 3
@@ -700,11 +863,13 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 				}
 
 				if dm.areStringsAt((current + 2), 1, "I", "E", "Y") {
+					dm.rule = "soft SC"
 					dm.addMetaphoneCharacter("S")
 					current += 3
 					break
 				}
 				//else
+				dm.rule = "SC"
 				dm.addMetaphoneCharacter("SK")
 				current += 3
 				break
@@ -712,8 +877,10 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 
 			//french e.g. 'resnais', 'artois'
 			if (current == dm.last) && dm.areStringsAt((current-2), 2, "AI", "OI") {
+				dm.rule = "french S"
 				dm.addMetaphoneCharacters("", "S")
 			} else {
+				dm.rule = "S"
 				dm.addMetaphoneCharacter("S")
 			}
 
@@ -725,12 +892,14 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 
 		case 'T':
 			if dm.areStringsAt(current, 4, "TION") {
+				dm.rule = "TION"
 				dm.addMetaphoneCharacter("X")
 				current += 3
 				break
 			}
 
 			if dm.areStringsAt(current, 3, "TIA", "TCH") {
+				dm.rule = "TIA, TCH"
 				dm.addMetaphoneCharacter("X")
 				current += 3
 				break
@@ -742,14 +911,17 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 				if dm.areStringsAt((current+2), 2, "OM", "AM") ||
 					dm.areStringsAt(0, 4, "VAN ", "VON ") ||
 					dm.areStringsAt(0, 3, "SCH") {
+					dm.rule = "germanic TH"
 					dm.addMetaphoneCharacter("T")
 				} else {
+					dm.rule = "TH"
 					dm.addMetaphoneCharacters("0", "T")
 				}
 				current += 2
 				break
 			}
 
+			dm.rule = "T"
 			if dm.areStringsAt((current + 1), 1, "T", "D") {
 				current += 2
 			} else {
@@ -758,6 +930,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			dm.addMetaphoneCharacter("T")
 
 		case 'V':
+			dm.rule = "V"
 			if dm.word[current+1] == 'V' {
 				current += 2
 			} else {
@@ -768,6 +941,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 		case 'W':
 			//can also be in middle of word
 			if dm.areStringsAt(current, 2, "WR") {
+				dm.rule = "WR"
 				dm.addMetaphoneCharacter("R")
 				current += 2
 				break
@@ -777,9 +951,11 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 				(dm.isVowel(current+1) || dm.areStringsAt(current, 2, "WH")) {
 				//Wasserman should match Vasserman
 				if dm.isVowel(current + 1) {
+					dm.rule = "initial W"
 					dm.addMetaphoneCharacters("A", "F")
 				} else {
 					//need Uomo to match Womo
+					dm.rule = "initial WH"
 					dm.addMetaphoneCharacter("A")
 				}
 			}
@@ -788,6 +964,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			if ((current == dm.last) && dm.isVowel(current-1)) ||
 				dm.areStringsAt((current-1), 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") ||
 				dm.areStringsAt(0, 3, "SCH") {
+				dm.rule = "Arnow W"
 				dm.addMetaphoneCharacters("", "F")
 				current += 1
 				break
@@ -795,6 +972,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 
 			//polish e.g. 'filipowicz'
 			if dm.areStringsAt(current, 4, "WICZ", "WITZ") {
+				dm.rule = "polish WICZ"
 				dm.addMetaphoneCharacters("TS", "FX")
 				current += 4
 				break
@@ -808,6 +986,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 			if !((current == dm.last) &&
 				(dm.areStringsAt((current-3), 3, "IAU", "EAU") ||
 					dm.areStringsAt((current-2), 2, "AU", "OU"))) {
+				dm.rule = "X"
 				dm.addMetaphoneCharacter("KS")
 			}
 
@@ -820,6 +999,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 		case 'Z':
 			//chinese pinyin e.g. 'zhao'
 			if dm.word[current+1] == 'H' {
+				dm.rule = "chinese ZH"
 				dm.addMetaphoneCharacter("J")
 				current += 2
 				break
@@ -827,8 +1007,10 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 
 				if dm.areStringsAt((current+1), 2, "ZO", "ZI", "ZA") ||
 					(dm.isWordSlavoGermanic() && ((current > 0) && dm.word[current-1] != 'T')) {
+					dm.rule = "slavic Z"
 					dm.addMetaphoneCharacters("S", "TS")
 				} else {
+					dm.rule = "Z"
 					dm.addMetaphoneCharacter("S")
 				}
 			}
@@ -842,6 +1024,8 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 		default:
 			current += 1
 		}
+
+		dm.recordStep(start, current, primaryStart, alternateStart)
 	}
 
 	//Finally, chop off the keys at the proscribed length
@@ -852,9 +1036,6 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 	if dm.alternateKeyLength > dm.maxKeyLength {
 		dm.alternateKey = dm.alternateKey[:dm.maxKeyLength]
 	}
-
-	dm.primaryKeyString = string(dm.primaryKey)
-	dm.alternateKeyString = string(dm.alternateKey)
 }
 
 /**
@@ -864,14 +1045,7 @@ func (dm *doubleMetaphone) buildMetaphoneKeys() {
 *         slavo-germanic origin; else false
  */
 func (dm *doubleMetaphone) isWordSlavoGermanic() bool {
-	if (strings.Contains(dm.word, "W")) ||
-		(strings.Contains(dm.word, "K")) ||
-		(strings.Contains(dm.word, "CZ")) ||
-		(strings.Contains(dm.word, "WITZ")) {
-		return true
-	}
-
-	return false
+	return dm.slavoGermanic
 }
 
 /**
@@ -919,7 +1093,7 @@ func (dm *doubleMetaphone) addMetaphoneCharacterPtr(primaryCharacter string, alt
 	if len(primaryCharacter) > 0 {
 		idx := 0
 		for idx < len(primaryCharacter) {
-			dm.primaryKey = append(dm.primaryKey, rune(primaryCharacter[idx]))
+			dm.primaryKey = append(dm.primaryKey, primaryCharacter[idx])
 			dm.primaryKeyLength++
 			idx++
 		}
@@ -935,7 +1109,7 @@ func (dm *doubleMetaphone) addMetaphoneCharacterPtr(primaryCharacter string, alt
 			if alternateCharacter[0] != ' ' {
 				idx := 0
 				for idx < len(alternateCharacter) {
-					dm.alternateKey = append(dm.alternateKey, rune(alternateCharacter[idx]))
+					dm.alternateKey = append(dm.alternateKey, alternateCharacter[idx])
 					dm.alternateKeyLength++
 					idx++
 				}
@@ -945,7 +1119,7 @@ func (dm *doubleMetaphone) addMetaphoneCharacterPtr(primaryCharacter string, alt
 			if len(primaryCharacter) > 0 && (primaryCharacter[0] != ' ') {
 				idx := 0
 				for idx < len(primaryCharacter) {
-					dm.alternateKey = append(dm.alternateKey, rune(primaryCharacter[idx]))
+					dm.alternateKey = append(dm.alternateKey, primaryCharacter[idx])
 					dm.alternateKeyLength++
 					idx++
 				}
@@ -955,7 +1129,7 @@ func (dm *doubleMetaphone) addMetaphoneCharacterPtr(primaryCharacter string, alt
 		//Else, no alternate character was passed, but a primary was, so append the primary character to the alternate key
 		idx := 0
 		for idx < len(primaryCharacter) {
-			dm.alternateKey = append(dm.alternateKey, rune(primaryCharacter[idx]))
+			dm.alternateKey = append(dm.alternateKey, primaryCharacter[idx])
 			dm.alternateKeyLength++
 			idx++
 		}
@@ -980,13 +1154,37 @@ func (dm *doubleMetaphone) areStringsAt(start int, length int, strs ...string) b
 		return false
 	}
 
+	if start+length > len(dm.word) {
+		return false
+	}
+
 	target := dm.word[start : start+length]
 
 	for idx := 0; idx < len(strs); idx++ {
-		if strs[idx] == target {
+		if runesEqualString(target, strs[idx]) {
 			return true
 		}
 	}
 
 	return false
 }
+
+/**
+* Compares a slice of runes to a string rune by rune, without allocating a new string
+*
+* @param runes  Runes to compare
+* @param str    String to compare the runes to
+*
+* @return true if runes and str hold exactly the same sequence of characters
+ */
+func runesEqualString(runes []rune, str string) bool {
+	idx := 0
+	for _, r := range str {
+		if idx >= len(runes) || runes[idx] != r {
+			return false
+		}
+		idx++
+	}
+
+	return idx == len(runes)
+}
@@ -421,6 +421,36 @@ func TestNonEnglishUnicode(t *testing.T) {
 			wantPrimary:   "ANTSTTT",
 			wantAlternate: nil,
 		},
+		{
+			name:          "test Françoise",
+			arg:           "Françoise",
+			wantPrimary:   "FRNSS",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test façade",
+			arg:           "façade",
+			wantPrimary:   "FST",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test ÇELIK",
+			arg:           "ÇELIK",
+			wantPrimary:   "SLK",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test Muñoz",
+			arg:           "Muñoz",
+			wantPrimary:   "MNS",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test Peña",
+			arg:           "Peña",
+			wantPrimary:   "PN",
+			wantAlternate: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1048,3 +1078,280 @@ func TestThWords(t *testing.T) {
 		})
 	}
 }
+
+func TestFoldWord(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		folding Folding
+		want    string
+	}{
+		{
+			name:    "test none",
+			arg:     "Müller",
+			folding: FOLD_NONE,
+			want:    "Müller",
+		},
+		{
+			name:    "test compose nfd",
+			arg:     "Mu\u0308ller",
+			folding: FOLD_COMPOSE,
+			want:    "Müller",
+		},
+		{
+			name:    "test german",
+			arg:     "Müller",
+			folding: FOLD_GERMAN,
+			want:    "Mueller",
+		},
+		{
+			name:    "test german nfd",
+			arg:     "Mu\u0308ller",
+			folding: FOLD_GERMAN,
+			want:    "Mueller",
+		},
+		{
+			name:    "test german sharp s",
+			arg:     "Strauß",
+			folding: FOLD_GERMAN,
+			want:    "Strauss",
+		},
+		{
+			name:    "test strip",
+			arg:     "Müller",
+			folding: FOLD_STRIP_DIACRITICS,
+			want:    "Muller",
+		},
+		{
+			name:    "test strip nfd",
+			arg:     "Jose\u0301",
+			folding: FOLD_STRIP_DIACRITICS,
+			want:    "Jose",
+		},
+		{
+			name:    "test strip no decomposition",
+			arg:     "Łukasz Søren",
+			folding: FOLD_STRIP_DIACRITICS,
+			want:    "Lukasz Soren",
+		},
+		{
+			name:    "test ligatures",
+			arg:     "Æthelred Œuvre",
+			folding: FOLD_LIGATURES,
+			want:    "AEthelred OEuvre",
+		},
+		{
+			name:    "test all",
+			arg:     "Müller-Lüdenscheidt Æsøp",
+			folding: FOLD_ALL,
+			want:    "Mueller-Luedenscheidt AEsop",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FoldWord(tt.arg, tt.folding); got != tt.want {
+				t.Errorf("TestFoldWord = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFoldingKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg1    string
+		arg2    string
+		folding Folding
+	}{
+		{
+			name:    "test Müller Mueller",
+			arg1:    "Müller",
+			arg2:    "Mueller",
+			folding: FOLD_GERMAN,
+		},
+		{
+			name:    "test Müller Muller",
+			arg1:    "Müller",
+			arg2:    "Muller",
+			folding: FOLD_STRIP_DIACRITICS,
+		},
+		{
+			name:    "test Strauß Strauss",
+			arg1:    "Strauß",
+			arg2:    "Strauss",
+			folding: FOLD_GERMAN,
+		},
+		{
+			name:    "test Françoise nfd",
+			arg1:    "Franc\u0327oise",
+			arg2:    "Françoise",
+			folding: FOLD_COMPOSE,
+		},
+		{
+			name:    "test Muñoz nfd",
+			arg1:    "Mun\u0303oz",
+			arg2:    "Muñoz",
+			folding: FOLD_COMPOSE,
+		},
+		{
+			name:    "test Æthelred",
+			arg1:    "Æthelred",
+			arg2:    "Aethelred",
+			folding: FOLD_LIGATURES,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewDoubleMetaphoneFolding(tt.arg1, tt.folding)
+			want := NewDoubleMetaphoneFolding(tt.arg2, tt.folding)
+			if got.PrimaryKey() != want.PrimaryKey() || !compareStringPointers(got.AlternateKey(), want.AlternateKey()) {
+				t.Errorf("TestFoldingKeys = %s %s, want %s %s", got.PrimaryKey(), safeString(got.AlternateKey()), want.PrimaryKey(), safeString(want.AlternateKey()))
+			}
+			if got.Word() != tt.arg1 {
+				t.Errorf("TestFoldingKeys word = %s, want %s", got.Word(), tt.arg1)
+			}
+		})
+	}
+}
+
+func TestPackedKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+	}{
+		{
+			name: "test peace",
+			arg:  "Peace",
+		},
+		{
+			name: "test Jablonski",
+			arg:  "Jablonski",
+		},
+		{
+			name: "test Kaplonski",
+			arg:  "Kaplonski",
+		},
+		{
+			name: "test schermerhorn",
+			arg:  "schermerhorn",
+		},
+		{
+			name: "test accident",
+			arg:  "accident",
+		},
+		{
+			name: "test cabrillo",
+			arg:  "cabrillo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dm := NewDoubleMetaphoneLimit(tt.arg, METAPHONE_KEY_LENGTH)
+			sdm := NewShortDoubleMetaphone(tt.arg)
+			if got := PackedKeyToString(sdm.PrimaryPackedKey()); got != dm.PrimaryKey() {
+				t.Errorf("TestPackedKeys primary = %s, want %s", got, dm.PrimaryKey())
+			}
+			if dm.AlternateKey() == nil {
+				if sdm.AlternatePackedKey() != METAPHONE_INVALID_PACKED_KEY || sdm.AlternateShortKey() != METAPHONE_INVALID_KEY {
+					t.Errorf("TestPackedKeys alternate = %x %x, want invalid key", sdm.AlternatePackedKey(), sdm.AlternateShortKey())
+				}
+			} else if got := PackedKeyToString(sdm.AlternatePackedKey()); got != *dm.AlternateKey() {
+				t.Errorf("TestPackedKeys alternate = %s, want %s", got, *dm.AlternateKey())
+			}
+		})
+	}
+}
+
+func TestShortKeysDoNotCollide(t *testing.T) {
+	//both keys end in "LNSK", which used to be all that survived packing into a uint16
+	sdm1 := NewShortDoubleMetaphone("Jablonski")
+	sdm2 := NewShortDoubleMetaphone("Kaplonski")
+	if sdm1.PrimaryShortKey() == sdm2.PrimaryShortKey() {
+		t.Errorf("TestShortKeysDoNotCollide short = %x %x, want different keys", sdm1.PrimaryShortKey(), sdm2.PrimaryShortKey())
+	}
+	if sdm1.PrimaryPackedKey() == sdm2.PrimaryPackedKey() {
+		t.Errorf("TestShortKeysDoNotCollide packed = %x %x, want different keys", sdm1.PrimaryPackedKey(), sdm2.PrimaryPackedKey())
+	}
+}
+
+func TestShortKeySymbolsRoundTrip(t *testing.T) {
+	for _, entry := range metaphoneCharacterCodes {
+		if entry.character == ' ' {
+			//never emitted into a key, and four of them would read as METAPHONE_INVALID_KEY
+			continue
+		}
+		t.Run("test symbol "+string(entry.character), func(t *testing.T) {
+			if entry.code == METAPHONE_NULL {
+				t.Errorf("TestShortKeySymbolsRoundTrip symbol %q maps to METAPHONE_NULL", entry.character)
+			}
+
+			shortKey := ""
+			for idx := 0; idx < METAPHONE_SHORT_KEY_LENGTH; idx++ {
+				shortKey += string(entry.character)
+			}
+			if got := ShortKeyToString(uint16(metaphoneKeyToPacked(shortKey))); got != shortKey {
+				t.Errorf("TestShortKeySymbolsRoundTrip short = %q, want %q", got, shortKey)
+			}
+
+			packedKey := ""
+			for idx := 0; idx < METAPHONE_KEY_LENGTH; idx++ {
+				packedKey += string(entry.character)
+			}
+			if got := PackedKeyToString(metaphoneKeyToPacked(packedKey)); got != packedKey {
+				t.Errorf("TestShortKeySymbolsRoundTrip packed = %q, want %q", got, packedKey)
+			}
+		})
+	}
+}
+
+func TestShortKeyEmittedSymbols(t *testing.T) {
+	//between them these words emit every symbol the string encoder produces
+	words := []string{"aubrey", "Wewski", "Jose", "biaggi", "richard", "Smith", "Schmidt", "maurice", "cambrillo", "catherine", "Jablonski", "zhao", "edgar", "Thomas"}
+	const emitted = "AFHJKLMNPRSTX0"
+
+	seen := map[rune]bool{}
+	for _, word := range words {
+		dm := NewDoubleMetaphoneLimit(word, METAPHONE_KEY_LENGTH)
+		sdm := NewShortDoubleMetaphone(word)
+		keys := []string{dm.PrimaryKey()}
+		packed := []uint32{sdm.PrimaryPackedKey()}
+		if dm.AlternateKey() != nil {
+			keys = append(keys, *dm.AlternateKey())
+			packed = append(packed, sdm.AlternatePackedKey())
+		}
+
+		for idx, key := range keys {
+			for _, symbol := range key {
+				seen[symbol] = true
+			}
+			if got := PackedKeyToString(packed[idx]); got != key {
+				t.Errorf("TestShortKeyEmittedSymbols %s = %q, want %q", word, got, key)
+			}
+		}
+	}
+
+	for _, symbol := range emitted {
+		if !seen[symbol] {
+			t.Errorf("TestShortKeyEmittedSymbols symbol %q not emitted by corpus", symbol)
+		}
+	}
+	for symbol := range seen {
+		if symbol > 0xff || characterToCode[symbol] == METAPHONE_NULL {
+			t.Errorf("TestShortKeyEmittedSymbols symbol %q has no short key code", symbol)
+		}
+	}
+}
+
+func TestShortKeyTheta(t *testing.T) {
+	smith := NewShortDoubleMetaphone("Smith")
+	smi := NewShortDoubleMetaphone("Smi")
+	if smith.PrimaryShortKey() == smi.PrimaryShortKey() {
+		t.Errorf("TestShortKeyTheta = %x %x, want different keys", smith.PrimaryShortKey(), smi.PrimaryShortKey())
+	}
+	if got := ShortKeyToString(smith.PrimaryShortKey()); got != "SM0" {
+		t.Errorf("TestShortKeyTheta = %s, want SM0", got)
+	}
+	if got := ShortKeyToString(METAPHONE_INVALID_KEY); got != "" {
+		t.Errorf("TestShortKeyTheta invalid = %q, want empty", got)
+	}
+}
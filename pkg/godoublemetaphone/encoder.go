@@ -0,0 +1,94 @@
+package godoublemetaphone
+
+import (
+	"math"
+)
+
+/**
+ * encoder.go
+ *
+ * A reusable Double Metaphone encoder for batch work.  NewDoubleMetaphone allocates a new
+ * instance, working buffers and key strings for every word; an Encoder keeps its buffers
+ * between calls, so once they have grown to fit the longest word seen, AppendEncode does
+ * not allocate at all and Encode allocates only the returned keys.
+ *
+ * An Encoder is not safe for concurrent use; use one per goroutine.
+ */
+
+type Encoder struct {
+	dm doubleMetaphone
+
+	///Scratch buffer holding both keys, converted to a string in a single allocation by Encode
+	keys []byte
+}
+
+/// <summary>Creates a reusable encoder producing keys of unlimited length</summary>
+func NewEncoder() *Encoder {
+	return newEncoder(math.MaxInt64, FOLD_NONE)
+}
+
+/// <summary>Creates a reusable encoder producing keys of at most maxKeyLength characters</summary>
+func NewEncoderLimit(maxKeyLength int) *Encoder {
+	return newEncoder(maxKeyLength, FOLD_NONE)
+}
+
+/// <summary>Creates a reusable encoder that applies the given Unicode folding to each word</summary>
+func NewEncoderFolding(folding Folding) *Encoder {
+	return newEncoder(math.MaxInt64, folding)
+}
+
+/// <summary>Creates a reusable encoder producing keys of at most maxKeyLength characters
+///     that applies the given Unicode folding to each word</summary>
+func NewEncoderLimitFolding(maxKeyLength int, folding Folding) *Encoder {
+	return newEncoder(maxKeyLength, folding)
+}
+
+func newEncoder(maxKeyLength int, folding Folding) *Encoder {
+	return &Encoder{
+		dm: doubleMetaphone{
+			maxKeyLength: maxKeyLength,
+			folding:      folding,
+		},
+	}
+}
+
+/// <summary>Computes the metaphone keys for word</summary>
+///
+/// <param name="word">Word whose metaphone keys are to be computed</param>
+///
+/// <returns>The primary key, and the alternate key or an empty string if the word has no
+///     alternate key by double metaphone</returns>
+func (e *Encoder) Encode(word string) (primary string, alternate string) {
+	e.dm.computeKeyBuffers(word)
+
+	if !e.dm.hasAlternate {
+		return string(e.dm.primaryKey), ""
+	}
+
+	//one allocation holding both keys, sliced into the two results
+	e.keys = append(e.keys[:0], e.dm.primaryKey...)
+	e.keys = append(e.keys, e.dm.alternateKey...)
+	keys := string(e.keys)
+	primaryLength := len(e.dm.primaryKey)
+
+	return keys[:primaryLength], keys[primaryLength:]
+}
+
+/// <summary>Computes the metaphone keys for word and appends them to dst</summary>
+///
+/// <param name="dst">Buffer to append to</param>
+/// <param name="word">Word whose metaphone keys are to be computed</param>
+///
+/// <returns>dst with the primary key appended, followed by a space and the alternate key
+///     if the word has an alternate key by double metaphone.  A space never occurs in a key</returns>
+func (e *Encoder) AppendEncode(dst []byte, word string) []byte {
+	e.dm.computeKeyBuffers(word)
+
+	dst = append(dst, e.dm.primaryKey...)
+	if e.dm.hasAlternate {
+		dst = append(dst, ' ')
+		dst = append(dst, e.dm.alternateKey...)
+	}
+
+	return dst
+}
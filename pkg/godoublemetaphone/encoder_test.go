@@ -0,0 +1,80 @@
+package godoublemetaphone
+
+import (
+	"testing"
+)
+
+var encoderWords = []string{"schermerhorn", "richard", "Jose", "aubrey", "Smith", "Françoise", "Muñoz", "", "Wewski", "biaggi", "mac caffrey", "Jablonski"}
+
+func TestEncoderMatchesDoubleMetaphone(t *testing.T) {
+	encoders := []struct {
+		name    string
+		encoder *Encoder
+		dm      func(word string) DoubleMetaphone
+	}{
+		{
+			name:    "test unlimited",
+			encoder: NewEncoder(),
+			dm:      NewDoubleMetaphone,
+		},
+		{
+			name:    "test limit",
+			encoder: NewEncoderLimit(4),
+			dm:      func(word string) DoubleMetaphone { return NewDoubleMetaphoneLimit(word, 4) },
+		},
+		{
+			name:    "test folding",
+			encoder: NewEncoderFolding(FOLD_ALL),
+			dm:      func(word string) DoubleMetaphone { return NewDoubleMetaphoneFolding(word, FOLD_ALL) },
+		},
+	}
+	for _, tt := range encoders {
+		t.Run(tt.name, func(t *testing.T) {
+			//the same encoder is reused for every word, so stale buffer contents would show up here
+			for _, word := range encoderWords {
+				want := tt.dm(word)
+				wantAlternate := ""
+				if want.AlternateKey() != nil {
+					wantAlternate = *want.AlternateKey()
+				}
+
+				primary, alternate := tt.encoder.Encode(word)
+				if primary != want.PrimaryKey() || alternate != wantAlternate {
+					t.Errorf("TestEncoderMatchesDoubleMetaphone %s = %s %s, want %s %s", word, primary, alternate, want.PrimaryKey(), wantAlternate)
+				}
+
+				wantAppend := "prefix:" + want.PrimaryKey()
+				if want.AlternateKey() != nil {
+					wantAppend += " " + wantAlternate
+				}
+				if got := string(tt.encoder.AppendEncode([]byte("prefix:"), word)); got != wantAppend {
+					t.Errorf("TestEncoderMatchesDoubleMetaphone %s append = %q, want %q", word, got, wantAppend)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEncoderEncode(b *testing.B) {
+	encoder := NewEncoder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		encoder.Encode(encoderWords[i%len(encoderWords)])
+	}
+}
+
+func BenchmarkEncoderAppendEncode(b *testing.B) {
+	encoder := NewEncoder()
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = encoder.AppendEncode(buf[:0], encoderWords[i%len(encoderWords)])
+	}
+}
+
+func BenchmarkNewDoubleMetaphoneWords(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewDoubleMetaphone(encoderWords[i%len(encoderWords)])
+	}
+}
@@ -0,0 +1,46 @@
+package godoublemetaphone
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+/**
+ * errors.go
+ *
+ * Errors for input that cannot be encoded.  NewDoubleMetaphone gives an empty primary key
+ * for an empty word, a word without letters or invalid UTF-8, so in an index all of them
+ * collide on the empty key; TryEncode reports them instead.
+ */
+
+var (
+	ErrEmptyInput         = errors.New("godoublemetaphone: word is empty")
+	ErrNoEncodableLetters = errors.New("godoublemetaphone: word has no letters to encode")
+	ErrInvalidUTF8        = errors.New("godoublemetaphone: word is not valid UTF-8")
+)
+
+/// <summary>Computes the double metaphone keys of word, configured by opts as New, and
+///     reports input whose keys would be empty</summary>
+///
+/// <returns>The keys, or nil and ErrInvalidUTF8 if word is not valid UTF-8, ErrEmptyInput
+///     if it is empty or only white space, or ErrNoEncodableLetters if its primary key is
+///     empty, as for digits, punctuation or only silent letters</returns>
+func TryEncode(word string, opts ...Option) (DoubleMetaphone, error) {
+	if !utf8.ValidString(word) {
+		return nil, ErrInvalidUTF8
+	}
+
+	if strings.TrimSpace(word) == "" {
+		return nil, ErrEmptyInput
+	}
+
+	dm := New(word, opts...)
+
+	//multi-word keys are separated by spaces
+	if strings.TrimSpace(dm.PrimaryKey()) == "" {
+		return nil, ErrNoEncodableLetters
+	}
+
+	return dm, nil
+}
@@ -0,0 +1,45 @@
+package godoublemetaphone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTryEncode(t *testing.T) {
+	tests := []struct {
+		name        string
+		word        string
+		opts        []Option
+		wantPrimary string
+		wantErr     error
+	}{
+		{name: "test word", word: "Smith", wantPrimary: "SM0"},
+		{name: "test options", word: "Jankelowicz", opts: []Option{WithMaxKeyLength(4)}, wantPrimary: "JNKL"},
+		{name: "test empty", word: "", wantErr: ErrEmptyInput},
+		{name: "test white space", word: " \t", wantErr: ErrEmptyInput},
+		{name: "test digits", word: "12345", wantErr: ErrNoEncodableLetters},
+		{name: "test punctuation", word: "-'.", wantErr: ErrNoEncodableLetters},
+		{name: "test silent letters", word: "HW", wantErr: ErrNoEncodableLetters},
+		{name: "test multi word digits", word: "12 34", opts: []Option{WithMultiWord(true)}, wantErr: ErrNoEncodableLetters},
+		{name: "test invalid utf8", word: "Sm\xffith", wantErr: ErrInvalidUTF8},
+		{name: "test zero max key length is unlimited", word: "Smith", opts: []Option{WithMaxKeyLength(0)}, wantPrimary: "SM0"},
+		{name: "test negative max key length is unlimited", word: "Smith", opts: []Option{WithMaxKeyLength(-1)}, wantPrimary: "SM0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TryEncode(tt.word, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TryEncode(%q) error = %v, want %v", tt.word, err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if got != nil {
+					t.Errorf("TryEncode(%q) = %v, want nil", tt.word, got.PrimaryKey())
+				}
+				return
+			}
+			if got.PrimaryKey() != tt.wantPrimary {
+				t.Errorf("TryEncode(%q) = %v, want %v", tt.word, got.PrimaryKey(), tt.wantPrimary)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package godoublemetaphone
+
+import (
+	"math"
+)
+
+/**
+ * explain.go
+ *
+ * Trace of the rules buildMetaphoneKeys applies to a word, for working out why a word
+ * got the keys it did.
+ */
+
+/// Step is one rule applied while computing the keys of a word
+type Step struct {
+	///Zero-based rune position in the word where the rule applied
+	Position int
+
+	///Input consumed by the rule, in upper case
+	Span string
+
+	///Name of the rule, e.g. "Schlesinger's rule" or "germanic CH"; "skipped" when the
+	///letters were passed over without a specific rule
+	Rule string
+
+	///Characters appended to the primary and alternate keys
+	Primary   string
+	Alternate string
+}
+
+/// <summary>Computes the metaphone keys for word, recording each rule applied</summary>
+///
+/// <param name="word">Word whose metaphone keys are to be explained</param>
+///
+/// <returns>The steps in the order they were applied</returns>
+func Explain(word string) []Step {
+	return ExplainLimit(word, math.MaxInt64)
+}
+
+/// <summary>Computes the metaphone keys for word, limited to maxKeyLength as by
+///     NewDoubleMetaphoneLimit, recording each rule applied.  No rules apply once both keys
+///     have reached maxKeyLength; the characters of the last step can run past it, as the
+///     keys are cut to length at the end</summary>
+func ExplainLimit(word string, maxKeyLength int) []Step {
+	dm := &doubleMetaphone{
+		maxKeyLength: maxKeyLength,
+		explain:      true,
+		steps:        []Step{},
+	}
+	dm.computeKeys(word)
+
+	return dm.steps
+}
+
+/// <summary>Records a step if explaining.  start and current delimit the input consumed,
+///     primaryStart and alternateStart are the key lengths before the rule applied</summary>
+func (dm *doubleMetaphone) recordStep(start int, current int, primaryStart int, alternateStart int) {
+	if !dm.explain {
+		return
+	}
+
+	end := current
+	if end > dm.length {
+		end = dm.length
+	}
+	rule := dm.rule
+	if rule == "" {
+		rule = "skipped"
+	}
+
+	dm.steps = append(dm.steps, Step{
+		Position:  start,
+		Span:      string(dm.word[start:end]),
+		Rule:      rule,
+		Primary:   string(dm.primaryKey[primaryStart:]),
+		Alternate: string(dm.alternateKey[alternateStart:]),
+	})
+}
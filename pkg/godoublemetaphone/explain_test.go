@@ -0,0 +1,185 @@
+package godoublemetaphone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want []Step
+	}{
+		{
+			name: "test schermerhorn",
+			arg:  "schermerhorn",
+			want: []Step{
+				{Position: 0, Span: "SCH", Rule: "Schlesinger's rule, dutch SCHER, SCHEN", Primary: "X", Alternate: "SK"},
+				{Position: 3, Span: "E", Rule: "vowel"},
+				{Position: 4, Span: "R", Rule: "R", Primary: "R", Alternate: "R"},
+				{Position: 5, Span: "M", Rule: "M", Primary: "M", Alternate: "M"},
+				{Position: 6, Span: "E", Rule: "vowel"},
+				{Position: 7, Span: "R", Rule: "R", Primary: "R", Alternate: "R"},
+				{Position: 8, Span: "H", Rule: "silent H"},
+				{Position: 9, Span: "O", Rule: "vowel"},
+				{Position: 10, Span: "R", Rule: "R", Primary: "R", Alternate: "R"},
+				{Position: 11, Span: "N", Rule: "N", Primary: "N", Alternate: "N"},
+			},
+		},
+		{
+			name: "test knight",
+			arg:  "knight",
+			want: []Step{
+				{Position: 0, Span: "K", Rule: "initial GN, KN, PN, WR, PS"},
+				{Position: 1, Span: "N", Rule: "N", Primary: "N", Alternate: "N"},
+				{Position: 2, Span: "I", Rule: "vowel"},
+				{Position: 3, Span: "GH", Rule: "GH"},
+				{Position: 5, Span: "T", Rule: "T", Primary: "T", Alternate: "T"},
+			},
+		},
+		{
+			name: "test michael",
+			arg:  "Michael",
+			want: []Step{
+				{Position: 0, Span: "M", Rule: "M", Primary: "M", Alternate: "M"},
+				{Position: 1, Span: "I", Rule: "vowel"},
+				{Position: 2, Span: "CH", Rule: "michael CHAE", Primary: "K", Alternate: "X"},
+				{Position: 4, Span: "A", Rule: "vowel"},
+				{Position: 5, Span: "E", Rule: "vowel"},
+				{Position: 6, Span: "L", Rule: "L", Primary: "L", Alternate: "L"},
+			},
+		},
+		{
+			name: "test empty",
+			arg:  "",
+			want: []Step{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Explain(tt.arg); fmt.Sprintf("%+v", got) != fmt.Sprintf("%+v", tt.want) {
+				t.Errorf("TestExplain = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplainMatchesKeys(t *testing.T) {
+	for _, word := range []string{"richard", "Jose", "cambrillo", "catherine", "Xavier", "Wewski", "biaggi", "Filipowicz", "San Jacinto", "Françoise"} {
+		t.Run("test "+word, func(t *testing.T) {
+			primary, alternate := "", ""
+			for _, step := range Explain(word) {
+				primary += step.Primary
+				alternate += step.Alternate
+			}
+
+			dm := NewDoubleMetaphone(word)
+			wantAlternate := dm.PrimaryKey()
+			if dm.AlternateKey() != nil {
+				wantAlternate = *dm.AlternateKey()
+			}
+			if primary != dm.PrimaryKey() || alternate != wantAlternate {
+				t.Errorf("TestExplainMatchesKeys = %s %s, want %s %s", primary, alternate, dm.PrimaryKey(), wantAlternate)
+			}
+		})
+	}
+}
+
+func TestExplainLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		arg          string
+		maxKeyLength int
+		wantSteps    int
+		wantPrimary  string
+	}{
+		{
+			name:         "test smith limit 2",
+			arg:          "Smith",
+			maxKeyLength: 2,
+			wantSteps:    2,
+			wantPrimary:  "SM",
+		},
+		{
+			name:         "test smith unlimited",
+			arg:          "Smith",
+			maxKeyLength: 100,
+			wantSteps:    len(Explain("Smith")),
+			wantPrimary:  "SM0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := ExplainLimit(tt.arg, tt.maxKeyLength)
+			primary := ""
+			for _, step := range steps {
+				primary += step.Primary
+			}
+			if len(steps) != tt.wantSteps || primary != tt.wantPrimary {
+				t.Errorf("TestExplainLimit = %d %s, want %d %s", len(steps), primary, tt.wantSteps, tt.wantPrimary)
+			}
+		})
+	}
+}
+
+func TestExplainRuleLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      string
+		wantRule string
+	}{
+		{
+			name:     "test jose",
+			arg:      "Jose",
+			wantRule: "spanish JOSE, SAN",
+		},
+		{
+			name:     "test san jacinto",
+			arg:      "San Jacinto",
+			wantRule: "spanish JOSE, SAN",
+		},
+		{
+			name:     "test josephine",
+			arg:      "Josephine",
+			wantRule: "JOSE inside word",
+		},
+		{
+			name:     "test schermerhorn",
+			arg:      "Schermerhorn",
+			wantRule: "Schlesinger's rule, dutch SCHER, SCHEN",
+		},
+		{
+			name:     "test school",
+			arg:      "School",
+			wantRule: "Schlesinger's rule, dutch SCH",
+		},
+		{
+			name:     "test cabrillo",
+			arg:      "Cabrillo",
+			wantRule: "spanish LL",
+		},
+		{
+			name:     "test miller",
+			arg:      "Miller",
+			wantRule: "LL",
+		},
+		{
+			name:     "test mila",
+			arg:      "Mila",
+			wantRule: "L",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := []string{}
+			for _, step := range Explain(tt.arg) {
+				rules = append(rules, step.Rule)
+				if step.Rule == tt.wantRule {
+					return
+				}
+			}
+			t.Errorf("TestExplainRuleLabels = %q, want %s", rules, tt.wantRule)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package godoublemetaphone
+
+import (
+	"strings"
+	"unicode"
+)
+
+/**
+ * folding.go
+ *
+ * Optional Unicode folding/normalization applied to a word before its metaphone keys
+ * are computed.  Double Metaphone only has rules for a handful of non-ASCII letters
+ * (Ç and Ñ), so names from mixed sources ("Müller", "Mueller", "Muller", or the same
+ * name in precomposed and decomposed form) otherwise take different paths through
+ * the rules.
+ *
+ * The tables below cover the Latin-1 Supplement and Latin Extended-A/B blocks, which
+ * is where European names live; no external normalization package is required.
+ */
+
+/// Folding is a set of FOLD_* flags selecting which normalizations to apply
+type Folding uint
+
+const (
+	FOLD_NONE Folding = 0
+
+	/// Compose base letters followed by combining marks into their precomposed form (NFC),
+	/// so decomposed (NFD) input encodes exactly like precomposed input
+	FOLD_COMPOSE Folding = 1 << (iota - 1)
+
+	/// Expand German umlauts and sharp s: Ä->AE, Ö->OE, Ü->UE, ß->SS
+	FOLD_GERMAN
+
+	/// Expand ligatures: Æ->AE, Œ->OE, Ĳ->IJ, ﬁ->FI, ...
+	FOLD_LIGATURES
+
+	/// Remove diacritics: é->E, Ø->O, Ł->L, ...  Applied after the German and ligature
+	/// expansions so those take precedence when combined
+	FOLD_STRIP_DIACRITICS
+
+	FOLD_ALL = FOLD_COMPOSE | FOLD_GERMAN | FOLD_LIGATURES | FOLD_STRIP_DIACRITICS
+)
+
+/// Canonical decompositions of precomposed Latin letters into a base letter and one combining mark
+var decompositionTable = map[rune][2]rune{
+	'\u00C0': {'A', '\u0300'}, '\u00C1': {'A', '\u0301'}, '\u00C2': {'A', '\u0302'}, '\u00C3': {'A', '\u0303'},
+	'\u00C4': {'A', '\u0308'}, '\u00C5': {'A', '\u030A'}, '\u00C7': {'C', '\u0327'}, '\u00C8': {'E', '\u0300'},
+	'\u00C9': {'E', '\u0301'}, '\u00CA': {'E', '\u0302'}, '\u00CB': {'E', '\u0308'}, '\u00CC': {'I', '\u0300'},
+	'\u00CD': {'I', '\u0301'}, '\u00CE': {'I', '\u0302'}, '\u00CF': {'I', '\u0308'}, '\u00D1': {'N', '\u0303'},
+	'\u00D2': {'O', '\u0300'}, '\u00D3': {'O', '\u0301'}, '\u00D4': {'O', '\u0302'}, '\u00D5': {'O', '\u0303'},
+	'\u00D6': {'O', '\u0308'}, '\u00D9': {'U', '\u0300'}, '\u00DA': {'U', '\u0301'}, '\u00DB': {'U', '\u0302'},
+	'\u00DC': {'U', '\u0308'}, '\u00DD': {'Y', '\u0301'}, '\u00E0': {'a', '\u0300'}, '\u00E1': {'a', '\u0301'},
+	'\u00E2': {'a', '\u0302'}, '\u00E3': {'a', '\u0303'}, '\u00E4': {'a', '\u0308'}, '\u00E5': {'a', '\u030A'},
+	'\u00E7': {'c', '\u0327'}, '\u00E8': {'e', '\u0300'}, '\u00E9': {'e', '\u0301'}, '\u00EA': {'e', '\u0302'},
+	'\u00EB': {'e', '\u0308'}, '\u00EC': {'i', '\u0300'}, '\u00ED': {'i', '\u0301'}, '\u00EE': {'i', '\u0302'},
+	'\u00EF': {'i', '\u0308'}, '\u00F1': {'n', '\u0303'}, '\u00F2': {'o', '\u0300'}, '\u00F3': {'o', '\u0301'},
+	'\u00F4': {'o', '\u0302'}, '\u00F5': {'o', '\u0303'}, '\u00F6': {'o', '\u0308'}, '\u00F9': {'u', '\u0300'},
+	'\u00FA': {'u', '\u0301'}, '\u00FB': {'u', '\u0302'}, '\u00FC': {'u', '\u0308'}, '\u00FD': {'y', '\u0301'},
+	'\u00FF': {'y', '\u0308'}, '\u0100': {'A', '\u0304'}, '\u0101': {'a', '\u0304'}, '\u0102': {'A', '\u0306'},
+	'\u0103': {'a', '\u0306'}, '\u0104': {'A', '\u0328'}, '\u0105': {'a', '\u0328'}, '\u0106': {'C', '\u0301'},
+	'\u0107': {'c', '\u0301'}, '\u0108': {'C', '\u0302'}, '\u0109': {'c', '\u0302'}, '\u010A': {'C', '\u0307'},
+	'\u010B': {'c', '\u0307'}, '\u010C': {'C', '\u030C'}, '\u010D': {'c', '\u030C'}, '\u010E': {'D', '\u030C'},
+	'\u010F': {'d', '\u030C'}, '\u0112': {'E', '\u0304'}, '\u0113': {'e', '\u0304'}, '\u0114': {'E', '\u0306'},
+	'\u0115': {'e', '\u0306'}, '\u0116': {'E', '\u0307'}, '\u0117': {'e', '\u0307'}, '\u0118': {'E', '\u0328'},
+	'\u0119': {'e', '\u0328'}, '\u011A': {'E', '\u030C'}, '\u011B': {'e', '\u030C'}, '\u011C': {'G', '\u0302'},
+	'\u011D': {'g', '\u0302'}, '\u011E': {'G', '\u0306'}, '\u011F': {'g', '\u0306'}, '\u0120': {'G', '\u0307'},
+	'\u0121': {'g', '\u0307'}, '\u0122': {'G', '\u0327'}, '\u0123': {'g', '\u0327'}, '\u0124': {'H', '\u0302'},
+	'\u0125': {'h', '\u0302'}, '\u0128': {'I', '\u0303'}, '\u0129': {'i', '\u0303'}, '\u012A': {'I', '\u0304'},
+	'\u012B': {'i', '\u0304'}, '\u012C': {'I', '\u0306'}, '\u012D': {'i', '\u0306'}, '\u012E': {'I', '\u0328'},
+	'\u012F': {'i', '\u0328'}, '\u0130': {'I', '\u0307'}, '\u0134': {'J', '\u0302'}, '\u0135': {'j', '\u0302'},
+	'\u0136': {'K', '\u0327'}, '\u0137': {'k', '\u0327'}, '\u0139': {'L', '\u0301'}, '\u013A': {'l', '\u0301'},
+	'\u013B': {'L', '\u0327'}, '\u013C': {'l', '\u0327'}, '\u013D': {'L', '\u030C'}, '\u013E': {'l', '\u030C'},
+	'\u0143': {'N', '\u0301'}, '\u0144': {'n', '\u0301'}, '\u0145': {'N', '\u0327'}, '\u0146': {'n', '\u0327'},
+	'\u0147': {'N', '\u030C'}, '\u0148': {'n', '\u030C'}, '\u014C': {'O', '\u0304'}, '\u014D': {'o', '\u0304'},
+	'\u014E': {'O', '\u0306'}, '\u014F': {'o', '\u0306'}, '\u0150': {'O', '\u030B'}, '\u0151': {'o', '\u030B'},
+	'\u0154': {'R', '\u0301'}, '\u0155': {'r', '\u0301'}, '\u0156': {'R', '\u0327'}, '\u0157': {'r', '\u0327'},
+	'\u0158': {'R', '\u030C'}, '\u0159': {'r', '\u030C'}, '\u015A': {'S', '\u0301'}, '\u015B': {'s', '\u0301'},
+	'\u015C': {'S', '\u0302'}, '\u015D': {'s', '\u0302'}, '\u015E': {'S', '\u0327'}, '\u015F': {'s', '\u0327'},
+	'\u0160': {'S', '\u030C'}, '\u0161': {'s', '\u030C'}, '\u0162': {'T', '\u0327'}, '\u0163': {'t', '\u0327'},
+	'\u0164': {'T', '\u030C'}, '\u0165': {'t', '\u030C'}, '\u0168': {'U', '\u0303'}, '\u0169': {'u', '\u0303'},
+	'\u016A': {'U', '\u0304'}, '\u016B': {'u', '\u0304'}, '\u016C': {'U', '\u0306'}, '\u016D': {'u', '\u0306'},
+	'\u016E': {'U', '\u030A'}, '\u016F': {'u', '\u030A'}, '\u0170': {'U', '\u030B'}, '\u0171': {'u', '\u030B'},
+	'\u0172': {'U', '\u0328'}, '\u0173': {'u', '\u0328'}, '\u0174': {'W', '\u0302'}, '\u0175': {'w', '\u0302'},
+	'\u0176': {'Y', '\u0302'}, '\u0177': {'y', '\u0302'}, '\u0178': {'Y', '\u0308'}, '\u0179': {'Z', '\u0301'},
+	'\u017A': {'z', '\u0301'}, '\u017B': {'Z', '\u0307'}, '\u017C': {'z', '\u0307'}, '\u017D': {'Z', '\u030C'},
+	'\u017E': {'z', '\u030C'}, '\u01A0': {'O', '\u031B'}, '\u01A1': {'o', '\u031B'}, '\u01AF': {'U', '\u031B'},
+	'\u01B0': {'u', '\u031B'}, '\u01CD': {'A', '\u030C'}, '\u01CE': {'a', '\u030C'}, '\u01CF': {'I', '\u030C'},
+	'\u01D0': {'i', '\u030C'}, '\u01D1': {'O', '\u030C'}, '\u01D2': {'o', '\u030C'}, '\u01D3': {'U', '\u030C'},
+	'\u01D4': {'u', '\u030C'}, '\u01D5': {'\u00DC', '\u0304'}, '\u01D6': {'\u00FC', '\u0304'}, '\u01D7': {'\u00DC', '\u0301'},
+	'\u01D8': {'\u00FC', '\u0301'}, '\u01D9': {'\u00DC', '\u030C'}, '\u01DA': {'\u00FC', '\u030C'}, '\u01DB': {'\u00DC', '\u0300'},
+	'\u01DC': {'\u00FC', '\u0300'}, '\u01DE': {'\u00C4', '\u0304'}, '\u01DF': {'\u00E4', '\u0304'}, '\u01E0': {'\u0226', '\u0304'},
+	'\u01E1': {'\u0227', '\u0304'}, '\u01E2': {'\u00C6', '\u0304'}, '\u01E3': {'\u00E6', '\u0304'}, '\u01E6': {'G', '\u030C'},
+	'\u01E7': {'g', '\u030C'}, '\u01E8': {'K', '\u030C'}, '\u01E9': {'k', '\u030C'}, '\u01EA': {'O', '\u0328'},
+	'\u01EB': {'o', '\u0328'}, '\u01EC': {'\u01EA', '\u0304'}, '\u01ED': {'\u01EB', '\u0304'}, '\u01EE': {'\u01B7', '\u030C'},
+	'\u01EF': {'\u0292', '\u030C'}, '\u01F0': {'j', '\u030C'}, '\u01F4': {'G', '\u0301'}, '\u01F5': {'g', '\u0301'},
+	'\u01F8': {'N', '\u0300'}, '\u01F9': {'n', '\u0300'}, '\u01FA': {'\u00C5', '\u0301'}, '\u01FB': {'\u00E5', '\u0301'},
+	'\u01FC': {'\u00C6', '\u0301'}, '\u01FD': {'\u00E6', '\u0301'}, '\u01FE': {'\u00D8', '\u0301'}, '\u01FF': {'\u00F8', '\u0301'},
+	'\u0200': {'A', '\u030F'}, '\u0201': {'a', '\u030F'}, '\u0202': {'A', '\u0311'}, '\u0203': {'a', '\u0311'},
+	'\u0204': {'E', '\u030F'}, '\u0205': {'e', '\u030F'}, '\u0206': {'E', '\u0311'}, '\u0207': {'e', '\u0311'},
+	'\u0208': {'I', '\u030F'}, '\u0209': {'i', '\u030F'}, '\u020A': {'I', '\u0311'}, '\u020B': {'i', '\u0311'},
+	'\u020C': {'O', '\u030F'}, '\u020D': {'o', '\u030F'}, '\u020E': {'O', '\u0311'}, '\u020F': {'o', '\u0311'},
+	'\u0210': {'R', '\u030F'}, '\u0211': {'r', '\u030F'}, '\u0212': {'R', '\u0311'}, '\u0213': {'r', '\u0311'},
+	'\u0214': {'U', '\u030F'}, '\u0215': {'u', '\u030F'}, '\u0216': {'U', '\u0311'}, '\u0217': {'u', '\u0311'},
+	'\u0218': {'S', '\u0326'}, '\u0219': {'s', '\u0326'}, '\u021A': {'T', '\u0326'}, '\u021B': {'t', '\u0326'},
+	'\u021E': {'H', '\u030C'}, '\u021F': {'h', '\u030C'}, '\u0226': {'A', '\u0307'}, '\u0227': {'a', '\u0307'},
+	'\u0228': {'E', '\u0327'}, '\u0229': {'e', '\u0327'}, '\u022A': {'\u00D6', '\u0304'}, '\u022B': {'\u00F6', '\u0304'},
+	'\u022C': {'\u00D5', '\u0304'}, '\u022D': {'\u00F5', '\u0304'}, '\u022E': {'O', '\u0307'}, '\u022F': {'o', '\u0307'},
+	'\u0230': {'\u022E', '\u0304'}, '\u0231': {'\u022F', '\u0304'}, '\u0232': {'Y', '\u0304'}, '\u0233': {'y', '\u0304'},
+}
+
+/// Reverse of decompositionTable, used to compose a base letter and combining mark
+var compositionTable = func() map[[2]rune]rune {
+	table := make(map[[2]rune]rune, len(decompositionTable))
+	for composed, parts := range decompositionTable {
+		table[parts] = composed
+	}
+	return table
+}()
+
+/// Letters with a diacritic that have no canonical decomposition
+var strippedTable = map[rune]string{
+	'Ø': "O", 'ø': "o", 'Đ': "D", 'đ': "d", 'Ð': "D", 'ð': "d",
+	'Ł': "L", 'ł': "l", 'Ħ': "H", 'ħ': "h", 'Ŧ': "T", 'ŧ': "t",
+	'ı': "i", 'Þ': "TH", 'þ': "th", 'ß': "ss", 'ẞ': "SS",
+}
+
+var germanTable = map[rune]string{
+	'Ä': "AE", 'ä': "ae", 'Ö': "OE", 'ö': "oe", 'Ü': "UE", 'ü': "ue",
+	'ß': "ss", 'ẞ': "SS",
+}
+
+var ligatureTable = map[rune]string{
+	'Æ': "AE", 'æ': "ae", 'Œ': "OE", 'œ': "oe", 'Ĳ': "IJ", 'ĳ': "ij",
+	'ﬀ': "ff", 'ﬁ': "fi", 'ﬂ': "fl", 'ﬃ': "ffi", 'ﬄ': "ffl", 'ﬅ': "st", 'ﬆ': "st",
+}
+
+/// <summary>Applies the requested folding to a word.  Returns the word unchanged for FOLD_NONE</summary>
+///
+/// <param name="word">Word to fold</param>
+/// <param name="folding">Combination of FOLD_* flags</param>
+func FoldWord(word string, folding Folding) string {
+	if folding == FOLD_NONE {
+		return word
+	}
+
+	//every other fold looks letters up in their precomposed form, so always compose first
+	runes := composeRunes(word)
+	if folding == FOLD_COMPOSE {
+		return string(runes)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(word))
+	for _, r := range runes {
+		if folding&FOLD_GERMAN != 0 {
+			if expansion, ok := germanTable[r]; ok {
+				sb.WriteString(expansion)
+				continue
+			}
+		}
+
+		if folding&FOLD_LIGATURES != 0 {
+			if expansion, ok := ligatureTable[r]; ok {
+				sb.WriteString(expansion)
+				continue
+			}
+		}
+
+		if folding&FOLD_STRIP_DIACRITICS != 0 {
+			if unicode.Is(unicode.Mn, r) {
+				continue
+			}
+			if stripped, ok := strippedTable[r]; ok {
+				sb.WriteString(stripped)
+				continue
+			}
+			for {
+				parts, ok := decompositionTable[r]
+				if !ok {
+					break
+				}
+				r = parts[0]
+			}
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+/**
+* Composes each base letter and following combining mark(s) into a precomposed letter where
+* one exists.  Combining marks with no precomposed form are kept as they are
+ */
+func composeRunes(word string) []rune {
+	runes := make([]rune, 0, len(word))
+	for _, r := range word {
+		if last := len(runes) - 1; last >= 0 && unicode.Is(unicode.Mn, r) {
+			if composed, ok := compositionTable[[2]rune{runes[last], r}]; ok {
+				runes[last] = composed
+				continue
+			}
+		}
+		runes = append(runes, r)
+	}
+
+	return runes
+}
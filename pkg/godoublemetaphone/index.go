@@ -0,0 +1,206 @@
+package godoublemetaphone
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+/**
+ * index.go
+ *
+ * An in-memory phonetic index storing IDs under the primary and alternate keys of their
+ * words, so candidates for a word can be looked up with their match strength.  Keyed on
+ * double metaphone by default, or on any PhoneticEncoder.  Safe for concurrent readers
+ * and writers.
+ */
+
+type PhoneticIndex[ID comparable] struct {
+	mu sync.RWMutex
+
+	encoder PhoneticEncoder
+
+	///IDs stored under each primary and each alternate key, with the number of their words having that key
+	primary   map[string]map[ID]int
+	alternate map[string]map[ID]int
+
+	///Keys of the words added for each ID, so an ID can be removed
+	records map[ID]*indexRecord
+
+	///Insertion counter, orders lookup results of equal strength
+	sequence int
+}
+
+type indexRecord struct {
+	sequence int
+	keys     []indexKeys
+}
+
+type indexKeys struct {
+	primary    string
+	alternates []string
+}
+
+/// IndexMatch is a candidate returned by PhoneticIndex.Lookup
+type IndexMatch[ID comparable] struct {
+	ID    ID
+	Level MatchLevel
+}
+
+/// <summary>Creates an empty index keyed on metaphone keys of unlimited length</summary>
+func NewPhoneticIndex[ID comparable]() *PhoneticIndex[ID] {
+	return NewPhoneticIndexLimit[ID](math.MaxInt64)
+}
+
+/// <summary>Creates an empty index keyed on metaphone keys of at most maxKeyLength characters</summary>
+func NewPhoneticIndexLimit[ID comparable](maxKeyLength int) *PhoneticIndex[ID] {
+	return NewPhoneticIndexEncoder[ID](DoubleMetaphoneEncoder{MaxKeyLength: maxKeyLength})
+}
+
+/// <summary>Creates an empty index keyed on the keys of encoder.  The first key of a word
+///     is its primary key, any further keys are alternates</summary>
+func NewPhoneticIndexEncoder[ID comparable](encoder PhoneticEncoder) *PhoneticIndex[ID] {
+	return &PhoneticIndex[ID]{
+		encoder:   encoder,
+		primary:   map[string]map[ID]int{},
+		alternate: map[string]map[ID]int{},
+		records:   map[ID]*indexRecord{},
+	}
+}
+
+/// <summary>The keys of word, without empty keys, as Compare never matches them</summary>
+///
+/// <returns>false if word has no primary key, e.g. digits or punctuation only</returns>
+func (idx *PhoneticIndex[ID]) keysFor(word string) (indexKeys, bool) {
+	keys := idx.encoder.Keys(word)
+	if len(keys) == 0 || keys[0] == "" {
+		return indexKeys{}, false
+	}
+
+	alternates := make([]string, 0, len(keys)-1)
+	for _, alternate := range keys[1:] {
+		if alternate != "" {
+			alternates = append(alternates, alternate)
+		}
+	}
+
+	return indexKeys{primary: keys[0], alternates: alternates}, true
+}
+
+/// <summary>Stores id under the primary and alternate keys of word.  An ID may be added
+///     with several words, e.g. a person's aliases.  Words without keys are not stored</summary>
+func (idx *PhoneticIndex[ID]) Add(id ID, word string) {
+	keys, ok := idx.keysFor(word)
+	if !ok {
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	record, ok := idx.records[id]
+	if !ok {
+		record = &indexRecord{sequence: idx.sequence}
+		idx.sequence++
+		idx.records[id] = record
+	}
+	record.keys = append(record.keys, keys)
+
+	addIndexKey(idx.primary, keys.primary, id)
+	for _, alternate := range keys.alternates {
+		addIndexKey(idx.alternate, alternate, id)
+	}
+}
+
+/// <summary>Removes id and every word added for it</summary>
+///
+/// <returns>false if id was not in the index</returns>
+func (idx *PhoneticIndex[ID]) Remove(id ID) bool {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	record, ok := idx.records[id]
+	if !ok {
+		return false
+	}
+
+	for _, keys := range record.keys {
+		removeIndexKey(idx.primary, keys.primary, id)
+		for _, alternate := range keys.alternates {
+			removeIndexKey(idx.alternate, alternate, id)
+		}
+	}
+	delete(idx.records, id)
+
+	return true
+}
+
+/// <summary>Number of IDs in the index</summary>
+func (idx *PhoneticIndex[ID]) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.records)
+}
+
+/// <summary>Finds the IDs whose words share a key with word</summary>
+///
+/// <returns>Each matching ID once with its strongest match level, strongest first; IDs of
+///     equal strength are in the order they were first added</returns>
+func (idx *PhoneticIndex[ID]) Lookup(word string) []IndexMatch[ID] {
+	keys, ok := idx.keysFor(word)
+	if !ok {
+		return []IndexMatch[ID]{}
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	levels := map[ID]MatchLevel{}
+	collect := func(bucket map[ID]int, level MatchLevel) {
+		for id := range bucket {
+			if level > levels[id] {
+				levels[id] = level
+			}
+		}
+	}
+
+	collect(idx.primary[keys.primary], MATCH_STRONG)
+	collect(idx.alternate[keys.primary], MATCH_NORMAL)
+	for _, alternate := range keys.alternates {
+		collect(idx.primary[alternate], MATCH_NORMAL)
+		collect(idx.alternate[alternate], MATCH_WEAK)
+	}
+
+	matches := make([]IndexMatch[ID], 0, len(levels))
+	for id, level := range levels {
+		matches = append(matches, IndexMatch[ID]{ID: id, Level: level})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Level != matches[j].Level {
+			return matches[i].Level > matches[j].Level
+		}
+		return idx.records[matches[i].ID].sequence < idx.records[matches[j].ID].sequence
+	})
+
+	return matches
+}
+
+func addIndexKey[ID comparable](keys map[string]map[ID]int, key string, id ID) {
+	bucket, ok := keys[key]
+	if !ok {
+		bucket = map[ID]int{}
+		keys[key] = bucket
+	}
+	bucket[id]++
+}
+
+func removeIndexKey[ID comparable](keys map[string]map[ID]int, key string, id ID) {
+	bucket := keys[key]
+	if bucket[id]--; bucket[id] <= 0 {
+		delete(bucket, id)
+	}
+	if len(bucket) == 0 {
+		delete(keys, key)
+	}
+}
@@ -0,0 +1,156 @@
+package godoublemetaphone
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestPhoneticIndexLookup(t *testing.T) {
+	idx := NewPhoneticIndex[int]()
+	idx.Add(1, "catherine")
+	idx.Add(2, "Schmidt")
+	idx.Add(3, "Yablonsky")
+	idx.Add(4, "Vewski")
+	idx.Add(5, "Jones")
+
+	tests := []struct {
+		name string
+		arg  string
+		want []IndexMatch[int]
+	}{
+		{
+			name: "test strong katherine",
+			arg:  "katherine",
+			want: []IndexMatch[int]{{ID: 1, Level: MATCH_STRONG}},
+		},
+		{
+			name: "test normal Smith",
+			arg:  "Smith",
+			want: []IndexMatch[int]{{ID: 2, Level: MATCH_NORMAL}},
+		},
+		{
+			name: "test normal Jablonski",
+			arg:  "Jablonski",
+			want: []IndexMatch[int]{{ID: 3, Level: MATCH_NORMAL}},
+		},
+		{
+			name: "test weak Wewski",
+			arg:  "Wewski",
+			want: []IndexMatch[int]{{ID: 4, Level: MATCH_WEAK}},
+		},
+		{
+			name: "test none",
+			arg:  "Peace",
+			want: []IndexMatch[int]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := idx.Lookup(tt.arg)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("TestPhoneticIndexLookup = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhoneticIndexEmptyKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoder PhoneticEncoder
+	}{
+		{
+			name:    "test double metaphone",
+			encoder: DoubleMetaphoneEncoder{},
+		},
+		{
+			name:    "test refined soundex",
+			encoder: RefinedSoundexEncoder{},
+		},
+		{
+			name:    "test nysiis",
+			encoder: NysiisEncoder{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := NewPhoneticIndexEncoder[int](tt.encoder)
+			idx.Add(1, "123")
+			idx.Add(2, "---")
+			idx.Add(3, "Smith")
+			if got := idx.Len(); got != 1 {
+				t.Errorf("TestPhoneticIndexEmptyKeys Len = %d, want 1", got)
+			}
+			if got := idx.Lookup("456"); len(got) != 0 {
+				t.Errorf("TestPhoneticIndexEmptyKeys = %v, want []", got)
+			}
+		})
+	}
+}
+
+func TestPhoneticIndexOrdering(t *testing.T) {
+	idx := NewPhoneticIndex[string]()
+	idx.Add("schmidt", "Schmidt")
+	idx.Add("smith-1", "Smith")
+	idx.Add("smyth", "Smyth")
+	idx.Add("smith-2", "Smith")
+
+	want := []IndexMatch[string]{
+		{ID: "smith-1", Level: MATCH_STRONG},
+		{ID: "smyth", Level: MATCH_STRONG},
+		{ID: "smith-2", Level: MATCH_STRONG},
+		{ID: "schmidt", Level: MATCH_NORMAL},
+	}
+	if got := idx.Lookup("Smith"); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("TestPhoneticIndexOrdering = %v, want %v", got, want)
+	}
+}
+
+func TestPhoneticIndexRemove(t *testing.T) {
+	idx := NewPhoneticIndex[int]()
+	idx.Add(1, "Smith")
+	idx.Add(1, "Schmidt")
+	idx.Add(2, "Smyth")
+
+	if !idx.Remove(1) || idx.Remove(1) {
+		t.Errorf("TestPhoneticIndexRemove remove = want true then false")
+	}
+	if idx.Len() != 1 {
+		t.Errorf("TestPhoneticIndexRemove len = %d, want 1", idx.Len())
+	}
+
+	want := []IndexMatch[int]{{ID: 2, Level: MATCH_STRONG}}
+	if got := idx.Lookup("Smith"); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("TestPhoneticIndexRemove = %v, want %v", got, want)
+	}
+	if len(idx.primary) != 1 || len(idx.alternate) != 1 {
+		t.Errorf("TestPhoneticIndexRemove buckets = %d %d, want 1 1", len(idx.primary), len(idx.alternate))
+	}
+}
+
+func TestPhoneticIndexConcurrent(t *testing.T) {
+	idx := NewPhoneticIndexLimit[int](METAPHONE_KEY_LENGTH)
+	words := batchWords(200)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 4; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i, word := range words {
+				id := worker*len(words) + i
+				idx.Add(id, word)
+				idx.Lookup(word)
+				if i%2 == 0 {
+					idx.Remove(id)
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	if idx.Len() != 4*len(words)/2 {
+		t.Errorf("TestPhoneticIndexConcurrent len = %d, want %d", idx.Len(), 4*len(words)/2)
+	}
+}
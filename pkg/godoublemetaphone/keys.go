@@ -0,0 +1,90 @@
+package godoublemetaphone
+
+import (
+	"encoding/json"
+)
+
+/**
+ * keys.go
+ *
+ * A comparable value holding the keys of a word.  DoubleMetaphone.AlternateKey returns a
+ * pointer that callers have to nil-check and that aliases the instance; Keys can be
+ * compared with ==, used as a map key and marshaled to JSON.
+ */
+
+/// Keys holds the double metaphone keys of a word
+type Keys struct {
+	Primary string
+
+	///Alternate key, an empty string if HasAlternate is false
+	Alternate    string
+	HasAlternate bool
+}
+
+/// JSON form of Keys; the alternate key is left out when there is none
+type keysJSON struct {
+	Primary   string  `json:"primary"`
+	Alternate *string `json:"alternate,omitempty"`
+}
+
+/// <summary>Computes the double metaphone keys of word, configured by opts as New</summary>
+func EncodeKeys(word string, opts ...Option) Keys {
+	return KeysOf(New(word, opts...))
+}
+
+/// <summary>Copies the keys of dm into a Keys value</summary>
+func KeysOf(dm DoubleMetaphone) Keys {
+	keys := Keys{Primary: dm.PrimaryKey()}
+	if alternate := dm.AlternateKey(); alternate != nil {
+		keys.Alternate = *alternate
+		keys.HasAlternate = true
+	}
+
+	return keys
+}
+
+/// <summary>The primary key, followed by the alternate key if there is one</summary>
+func (keys Keys) All() []string {
+	if keys.HasAlternate {
+		return []string{keys.Primary, keys.Alternate}
+	}
+
+	return []string{keys.Primary}
+}
+
+/// <summary>The primary key, or the primary and alternate key separated by '/'</summary>
+func (keys Keys) String() string {
+	if keys.HasAlternate {
+		return keys.Primary + "/" + keys.Alternate
+	}
+
+	return keys.Primary
+}
+
+/// <summary>Marshals keys as {"primary":"SM0","alternate":"XMT"}, without "alternate" if
+///     there is no alternate key</summary>
+func (keys Keys) MarshalJSON() ([]byte, error) {
+	out := keysJSON{Primary: keys.Primary}
+	if keys.HasAlternate {
+		out.Alternate = &keys.Alternate
+	}
+
+	return json.Marshal(out)
+}
+
+/// <summary>Unmarshals the form written by MarshalJSON; HasAlternate is set when
+///     "alternate" is present</summary>
+func (keys *Keys) UnmarshalJSON(data []byte) error {
+	var in keysJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*keys = Keys{Primary: in.Primary}
+	if in.Alternate != nil {
+		keys.Alternate = *in.Alternate
+		keys.HasAlternate = true
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package godoublemetaphone
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		word       string
+		opts       []Option
+		want       Keys
+		wantString string
+		wantAll    []string
+		wantJSON   string
+	}{
+		{
+			name:       "test alternate",
+			word:       "Smith",
+			want:       Keys{Primary: "SM0", Alternate: "XMT", HasAlternate: true},
+			wantString: "SM0/XMT",
+			wantAll:    []string{"SM0", "XMT"},
+			wantJSON:   `{"primary":"SM0","alternate":"XMT"}`,
+		},
+		{
+			name:       "test no alternate",
+			word:       "Thomas",
+			want:       Keys{Primary: "TMS"},
+			wantString: "TMS",
+			wantAll:    []string{"TMS"},
+			wantJSON:   `{"primary":"TMS"}`,
+		},
+		{
+			name:       "test max key length",
+			word:       "Jankelowicz",
+			opts:       []Option{WithMaxKeyLength(4)},
+			want:       Keys{Primary: "JNKL", Alternate: "ANKL", HasAlternate: true},
+			wantString: "JNKL/ANKL",
+			wantAll:    []string{"JNKL", "ANKL"},
+			wantJSON:   `{"primary":"JNKL","alternate":"ANKL"}`,
+		},
+		{
+			name:       "test empty",
+			word:       "",
+			want:       Keys{},
+			wantString: "",
+			wantAll:    []string{""},
+			wantJSON:   `{"primary":""}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EncodeKeys(tt.word, tt.opts...)
+			if got != tt.want {
+				t.Errorf("TestEncodeKeys = %+v, want %+v", got, tt.want)
+			}
+			if got != KeysOf(New(tt.word, tt.opts...)) {
+				t.Errorf("TestEncodeKeys KeysOf = %+v, want %+v", KeysOf(New(tt.word, tt.opts...)), got)
+			}
+			if got.String() != tt.wantString {
+				t.Errorf("TestEncodeKeys String = %s, want %s", got.String(), tt.wantString)
+			}
+			if !reflect.DeepEqual(got.All(), tt.wantAll) {
+				t.Errorf("TestEncodeKeys All = %q, want %q", got.All(), tt.wantAll)
+			}
+
+			data, err := json.Marshal(got)
+			if err != nil || string(data) != tt.wantJSON {
+				t.Fatalf("TestEncodeKeys json.Marshal = %s %v, want %s", data, err, tt.wantJSON)
+			}
+			var back Keys
+			if err := json.Unmarshal(data, &back); err != nil || back != got {
+				t.Errorf("TestEncodeKeys json.Unmarshal = %+v %v, want %+v", back, err, got)
+			}
+		})
+	}
+
+	if err := json.Unmarshal([]byte(`["SM0"]`), &Keys{}); err == nil {
+		t.Errorf("TestEncodeKeys json.Unmarshal of an array = nil, want an error")
+	}
+}
+
+func TestKeysComparable(t *testing.T) {
+	counts := map[Keys]int{}
+	for _, word := range []string{"Smith", "Smyth", "Schmidt", "Thomas"} {
+		counts[EncodeKeys(word)]++
+	}
+	if counts[Keys{Primary: "SM0", Alternate: "XMT", HasAlternate: true}] != 2 {
+		t.Errorf("TestKeysComparable = %v, want 2 keys SM0/XMT", counts)
+	}
+}
@@ -0,0 +1,149 @@
+package godoublemetaphone
+
+import (
+	"strings"
+	"unicode"
+)
+
+/**
+ * koelnerphonetik.go
+ *
+ * Kölner Phonetik (Cologne phonetics), published by Hans Joachim Postel in 1969 for
+ * German names.  Letters are coded as the digits 0-8 depending on their neighbours,
+ * repeated digits are coded once and vowels only at the start of the name.  Umlauts are
+ * coded as their base vowels and ß as S; other accented letters are folded to their base
+ * letters.  The rules follow the Apache Commons Codec implementation.
+ */
+
+type KoelnerPhonetik interface {
+	Key() string
+	Word() string
+}
+
+type koelnerPhonetik struct {
+	keyString    string
+	originalWord string
+}
+
+/// Letters for which a following 'C' codes as '4' at the start of a word
+const koelnerInitialHardC = "AHKLOQRUX"
+
+/// Letters for which a following 'C' codes as '4' inside a word
+const koelnerHardC = "AHKOQUX"
+
+func NewKoelnerPhonetik(word string) KoelnerPhonetik {
+	return &koelnerPhonetik{
+		keyString:    koelnerPhonetikKey(koelnerLetters(word)),
+		originalWord: word,
+	}
+}
+
+/// <summary>The Kölner Phonetik code for the word, a string of digits.  Empty if the word
+///     has no letters</summary>
+func (kp *koelnerPhonetik) Key() string {
+	return kp.keyString
+}
+
+/// <summary>Original word for which the code was computed</summary>
+func (kp *koelnerPhonetik) Word() string {
+	return kp.originalWord
+}
+
+/// KoelnerPhonetikEncoder is the PhoneticEncoder for Kölner Phonetik
+type KoelnerPhonetikEncoder struct{}
+
+func (KoelnerPhonetikEncoder) Keys(word string) []string {
+	return []string{koelnerPhonetikKey(koelnerLetters(word))}
+}
+
+/// <summary>The upper case letters A-Z of word, with umlauts and ß replaced by their base
+///     letters and other accented letters folded</summary>
+func koelnerLetters(word string) []byte {
+	var sb strings.Builder
+	for _, r := range word {
+		switch unicode.ToUpper(r) {
+		case 'Ä':
+			sb.WriteByte('A')
+		case 'Ö':
+			sb.WriteByte('O')
+		case 'Ü':
+			sb.WriteByte('U')
+		case 'ß', 'ẞ':
+			sb.WriteByte('S')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return asciiUpperLetters(FoldWord(sb.String(), FOLD_COMPOSE|FOLD_LIGATURES|FOLD_STRIP_DIACRITICS))
+}
+
+/**
+* Internal impl of Kölner Phonetik on the upper case letters of the word
+ */
+func koelnerPhonetikKey(letters []byte) string {
+	key := make([]byte, 0, len(letters)+1)
+
+	//'/' marks the start of the word, '-' a letter that is not coded
+	var lastLetter, lastCode byte = 0, '/'
+	for current, letter := range letters {
+		var next byte
+		if current+1 < len(letters) {
+			next = letters[current+1]
+		}
+
+		var code byte
+		switch {
+		case strings.IndexByte("AEIJOUY", letter) >= 0:
+			code = '0'
+		case letter == 'B' || letter == 'P' && next != 'H':
+			code = '1'
+		case (letter == 'D' || letter == 'T') && strings.IndexByte("CSZ", next) < 0:
+			code = '2'
+		case strings.IndexByte("WFPV", letter) >= 0:
+			code = '3'
+		case strings.IndexByte("GKQ", letter) >= 0:
+			code = '4'
+		case letter == 'X' && strings.IndexByte("CKQ", lastLetter) < 0:
+			//X is coded as KS
+			if lastCode != '4' {
+				key = append(key, '4')
+			}
+			code = '8'
+		case letter == 'S' || letter == 'Z':
+			code = '8'
+		case letter == 'C':
+			switch {
+			case lastCode == '/':
+				code = '8'
+				if strings.IndexByte(koelnerInitialHardC, next) >= 0 {
+					code = '4'
+				}
+			case strings.IndexByte("SZ", lastLetter) >= 0 || strings.IndexByte(koelnerHardC, next) < 0:
+				code = '8'
+			default:
+				code = '4'
+			}
+		case strings.IndexByte("TDX", letter) >= 0:
+			code = '8'
+		case letter == 'R':
+			code = '7'
+		case letter == 'L':
+			code = '5'
+		case letter == 'M' || letter == 'N':
+			code = '6'
+		default:
+			//H
+			code = '-'
+		}
+
+		if code != '-' && code != lastCode && (code != '0' || lastCode == '/') {
+			key = append(key, code)
+		}
+
+		lastLetter = letter
+		lastCode = code
+	}
+
+	return string(key)
+}
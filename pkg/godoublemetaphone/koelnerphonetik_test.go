@@ -0,0 +1,311 @@
+package godoublemetaphone
+
+import (
+	"testing"
+)
+
+func TestKoelnerPhonetik(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{
+			name: "test Müller-Lüdenscheidt",
+			arg:  "Müller-Lüdenscheidt",
+			want: "65752682",
+		},
+		{
+			name: "test Wikipedia",
+			arg:  "Wikipedia",
+			want: "3412",
+		},
+		{
+			name: "test Breschnew",
+			arg:  "Breschnew",
+			want: "17863",
+		},
+		{
+			name: "test Xaver initial X",
+			arg:  "Xaver",
+			want: "4837",
+		},
+		{
+			name: "test Axel",
+			arg:  "Axel",
+			want: "0485",
+		},
+		{
+			name: "test Chemnitz initial C hard",
+			arg:  "Chemnitz",
+			want: "468",
+		},
+		{
+			name: "test Celle initial C soft",
+			arg:  "Celle",
+			want: "85",
+		},
+		{
+			name: "test Koch",
+			arg:  "Koch",
+			want: "44",
+		},
+		{
+			name: "test Philipp PH",
+			arg:  "Philipp",
+			want: "351",
+		},
+		{
+			name: "test Straße ß",
+			arg:  "Straße",
+			want: "8278",
+		},
+		{
+			name: "test no letters",
+			arg:  "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kp := NewKoelnerPhonetik(tt.arg)
+			if got := kp.Key(); got != tt.want {
+				t.Errorf("TestKoelnerPhonetik = %s, want %s", got, tt.want)
+			}
+			if kp.Word() != tt.arg {
+				t.Errorf("TestKoelnerPhonetik Word = %s, want %s", kp.Word(), tt.arg)
+			}
+		})
+	}
+}
+
+func TestVariousGermanKoelner(t *testing.T) {
+	tests := []struct {
+		name          string
+		arg           string
+		wantKoelner   string
+		wantPrimary   string
+		wantAlternate *string
+	}{
+		{
+			name:          "test ach",
+			arg:           "ach",
+			wantKoelner:   "04",
+			wantPrimary:   "AK",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test bacher",
+			arg:           "bacher",
+			wantKoelner:   "147",
+			wantPrimary:   "PKR",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test macher",
+			arg:           "macher",
+			wantKoelner:   "647",
+			wantPrimary:   "MKR",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test Meier",
+			arg:           "Meier",
+			wantKoelner:   "67",
+			wantPrimary:   "MR",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test Mayr",
+			arg:           "Mayr",
+			wantKoelner:   "67",
+			wantPrimary:   "MR",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test Schmidt",
+			arg:           "Schmidt",
+			wantKoelner:   "862",
+			wantPrimary:   "XMT",
+			wantAlternate: stringPtr("SMT"),
+		},
+		{
+			name:          "test Schmitt",
+			arg:           "Schmitt",
+			wantKoelner:   "862",
+			wantPrimary:   "XMT",
+			wantAlternate: stringPtr("SMT"),
+		},
+		{
+			name:          "test Müller",
+			arg:           "Müller",
+			wantKoelner:   "657",
+			wantPrimary:   "MLR",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test Mueller",
+			arg:           "Mueller",
+			wantKoelner:   "657",
+			wantPrimary:   "MLR",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test Fischer",
+			arg:           "Fischer",
+			wantKoelner:   "387",
+			wantPrimary:   "FXR",
+			wantAlternate: stringPtr("FSKR"),
+		},
+		{
+			name:          "test Bäcker",
+			arg:           "Bäcker",
+			wantKoelner:   "147",
+			wantPrimary:   "PKR",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test Wagner",
+			arg:           "Wagner",
+			wantKoelner:   "3467",
+			wantPrimary:   "AKNR",
+			wantAlternate: stringPtr("FKNR"),
+		},
+		{
+			name:          "test Weiß",
+			arg:           "Weiß",
+			wantKoelner:   "38",
+			wantPrimary:   "A",
+			wantAlternate: stringPtr("F"),
+		},
+		{
+			name:          "test Weiss",
+			arg:           "Weiss",
+			wantKoelner:   "38",
+			wantPrimary:   "AS",
+			wantAlternate: stringPtr("FS"),
+		},
+		{
+			name:          "test Schulz",
+			arg:           "Schulz",
+			wantKoelner:   "858",
+			wantPrimary:   "XLS",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test Schultz",
+			arg:           "Schultz",
+			wantKoelner:   "858",
+			wantPrimary:   "XLTS",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test Schröder",
+			arg:           "Schröder",
+			wantKoelner:   "8727",
+			wantPrimary:   "XRTR",
+			wantAlternate: stringPtr("SRTR"),
+		},
+		{
+			name:          "test Krüger",
+			arg:           "Krüger",
+			wantKoelner:   "4747",
+			wantPrimary:   "KRKR",
+			wantAlternate: stringPtr("KRJR"),
+		},
+		{
+			name:          "test Krueger",
+			arg:           "Krueger",
+			wantKoelner:   "4747",
+			wantPrimary:   "KRJR",
+			wantAlternate: stringPtr("KRKR"),
+		},
+		{
+			name:          "test Hoffmann",
+			arg:           "Hoffmann",
+			wantKoelner:   "366",
+			wantPrimary:   "HFMN",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test Zimmermann",
+			arg:           "Zimmermann",
+			wantKoelner:   "86766",
+			wantPrimary:   "SMRMN",
+			wantAlternate: nil,
+		},
+		{
+			name:          "test Jäger",
+			arg:           "Jäger",
+			wantKoelner:   "047",
+			wantPrimary:   "JKR",
+			wantAlternate: stringPtr("AJR"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewKoelnerPhonetik(tt.arg).Key(); got != tt.wantKoelner {
+				t.Errorf("TestVariousGermanKoelner Koelner = %s, want %s", got, tt.wantKoelner)
+			}
+			if got := NewDoubleMetaphone(tt.arg); got.PrimaryKey() != tt.wantPrimary || !compareStringPointers(got.AlternateKey(), tt.wantAlternate) {
+				t.Errorf("TestVariousGermanKoelner = %s %s, want %s %s", got.PrimaryKey(), safeString(got.AlternateKey()), tt.wantPrimary, safeString(tt.wantAlternate))
+			}
+		})
+	}
+}
+
+func TestGermanSpellingVariants(t *testing.T) {
+	//spellings a German reader pronounces alike; Double Metaphone does not match all of them
+	tests := []struct {
+		name        string
+		a, b        string
+		wantDouble  MatchLevel
+		wantKoelner MatchLevel
+	}{
+		{
+			name:        "test Weiß Weiss",
+			a:           "Weiß",
+			b:           "Weiss",
+			wantDouble:  MATCH_NONE,
+			wantKoelner: MATCH_STRONG,
+		},
+		{
+			name:        "test Schulz Schultz",
+			a:           "Schulz",
+			b:           "Schultz",
+			wantDouble:  MATCH_NONE,
+			wantKoelner: MATCH_STRONG,
+		},
+		{
+			name:        "test Straße Strasse",
+			a:           "Straße",
+			b:           "Strasse",
+			wantDouble:  MATCH_NONE,
+			wantKoelner: MATCH_STRONG,
+		},
+		{
+			name:        "test Krüger Krueger",
+			a:           "Krüger",
+			b:           "Krueger",
+			wantDouble:  MATCH_NORMAL,
+			wantKoelner: MATCH_STRONG,
+		},
+		{
+			name:        "test Meier Mayr",
+			a:           "Meier",
+			b:           "Mayr",
+			wantDouble:  MATCH_STRONG,
+			wantKoelner: MATCH_STRONG,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareWith(DoubleMetaphoneEncoder{}, tt.a, tt.b); got != tt.wantDouble {
+				t.Errorf("TestGermanSpellingVariants double metaphone = %v, want %v", got, tt.wantDouble)
+			}
+			if got := CompareWith(KoelnerPhonetikEncoder{}, tt.a, tt.b); got != tt.wantKoelner {
+				t.Errorf("TestGermanSpellingVariants Koelner = %v, want %v", got, tt.wantKoelner)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package godoublemetaphone
+
+/**
+ * match.go
+ *
+ * Match strength between two words by Phillips' scheme: which of their primary and
+ * alternate keys coincide.  Pairs of names can also be rated by the Match Rating
+ * Approach.
+ */
+
+/// MatchLevel is the strength of a match between the keys of two words
+type MatchLevel int
+
+const (
+	MATCH_NONE   MatchLevel = iota ///No keys in common
+	MATCH_WEAK                     ///Alternate key matches alternate key
+	MATCH_NORMAL                   ///Primary key of one matches alternate key of the other
+	MATCH_STRONG                   ///Primary key matches primary key
+)
+
+func (level MatchLevel) String() string {
+	switch level {
+	case MATCH_WEAK:
+		return "weak"
+	case MATCH_NORMAL:
+		return "normal"
+	case MATCH_STRONG:
+		return "strong"
+	}
+
+	return "none"
+}
+
+/// <summary>Classifies how strongly two words match by Phillips' scheme, from their
+///     double metaphone keys.  Empty keys never match</summary>
+///
+/// <returns>MATCH_STRONG if the primary keys match, MATCH_NORMAL if the primary key of one
+///     matches the alternate key of the other, MATCH_WEAK if only the alternate keys match,
+///     else MATCH_NONE</returns>
+func Compare(a DoubleMetaphone, b DoubleMetaphone) MatchLevel {
+	return compareKeys(a.PrimaryKey(), a.AlternateKey(), b.PrimaryKey(), b.AlternateKey())
+}
+
+/// <summary>Computes the double metaphone keys of both words and classifies how strongly
+///     they match, see Compare</summary>
+func CompareWords(a string, b string) MatchLevel {
+	return Compare(NewDoubleMetaphone(a), NewDoubleMetaphone(b))
+}
+
+/// <summary>Computes the keys of both words with encoder and classifies how strongly they
+///     match, so the scheme can be used with any algorithm.  The first key of a word is its
+///     primary key, any further keys are alternates</summary>
+func CompareWith(encoder PhoneticEncoder, a string, b string) MatchLevel {
+	keysA := encoder.Keys(a)
+	keysB := encoder.Keys(b)
+	if len(keysA) == 0 || len(keysB) == 0 {
+		return MATCH_NONE
+	}
+
+	//an empty alternate never matches, so it stands for comparing the primary keys alone
+	best := MATCH_NONE
+	for _, alternateA := range append([]string{""}, keysA[1:]...) {
+		for _, alternateB := range append([]string{""}, keysB[1:]...) {
+			if level := compareKeys(keysA[0], &alternateA, keysB[0], &alternateB); level > best {
+				best = level
+			}
+		}
+	}
+
+	return best
+}
+
+/// MatchRating is the Match Rating Approach comparison of two names
+type MatchRating struct {
+	///Codices of the two names
+	CodexA string
+	CodexB string
+
+	///Similarity rating from 0 to 6, and the rating the names need to match
+	Rating        int
+	MinimumRating int
+
+	///Whether the names match: their codices differ in length by less than 3 and the
+	///rating reaches the minimum
+	Match bool
+}
+
+/// <summary>Rates the similarity of two names by the Match Rating Approach.  Letters
+///     at the same position are removed from both codices, first from the left, then from
+///     the right of what remains; the rating is 6 less the letters left in the longer
+///     codex.  The minimum rating depends on the combined length of the codices</summary>
+func CompareMatchRating(a string, b string) MatchRating {
+	rating := MatchRating{CodexA: MatchRatingCodex(a), CodexB: MatchRatingCodex(b)}
+	if rating.CodexA == "" || rating.CodexB == "" {
+		return rating
+	}
+
+	lengthA, lengthB := len(rating.CodexA), len(rating.CodexB)
+	rating.MinimumRating = matchRatingMinimum(lengthA + lengthB)
+	if lengthA-lengthB >= 3 || lengthB-lengthA >= 3 {
+		return rating
+	}
+
+	//left to right, then right to left on the unmatched letters
+	restA, restB := []byte(rating.CodexA), []byte(rating.CodexB)
+	restA, restB = removeMatchingLetters(restA, restB, false)
+	restA, restB = removeMatchingLetters(restA, restB, true)
+
+	unmatched := len(restA)
+	if len(restB) > unmatched {
+		unmatched = len(restB)
+	}
+	rating.Rating = MATCH_RATING_CODEX_LENGTH - unmatched
+	rating.Match = rating.Rating >= rating.MinimumRating
+
+	return rating
+}
+
+/// <summary>Minimum rating for codices of the given combined length to match</summary>
+func matchRatingMinimum(combinedLength int) int {
+	switch {
+	case combinedLength <= 4:
+		return 5
+	case combinedLength <= 7:
+		return 4
+	case combinedLength <= 11:
+		return 3
+	case combinedLength == 12:
+		return 2
+	}
+
+	return 1
+}
+
+/// <summary>Removes the letters equal at the same position of both codices, counted from
+///     the left or from the right</summary>
+func removeMatchingLetters(a []byte, b []byte, fromRight bool) ([]byte, []byte) {
+	var restA, restB []byte
+	length := len(a)
+	if len(b) < length {
+		length = len(b)
+	}
+
+	matched := make([]bool, length)
+	for idx := 0; idx < length; idx++ {
+		posA, posB := idx, idx
+		if fromRight {
+			posA, posB = len(a)-1-idx, len(b)-1-idx
+		}
+		matched[idx] = a[posA] == b[posB]
+	}
+
+	for idx := range a {
+		pos := idx
+		if fromRight {
+			pos = len(a) - 1 - idx
+		}
+		if pos >= length || !matched[pos] {
+			restA = append(restA, a[idx])
+		}
+	}
+	for idx := range b {
+		pos := idx
+		if fromRight {
+			pos = len(b) - 1 - idx
+		}
+		if pos >= length || !matched[pos] {
+			restB = append(restB, b[idx])
+		}
+	}
+
+	return restA, restB
+}
+
+func compareKeys(primaryA string, alternateA *string, primaryB string, alternateB *string) MatchLevel {
+	if keysMatch(&primaryA, &primaryB) {
+		return MATCH_STRONG
+	}
+
+	if keysMatch(&primaryA, alternateB) || keysMatch(alternateA, &primaryB) {
+		return MATCH_NORMAL
+	}
+
+	if keysMatch(alternateA, alternateB) {
+		return MATCH_WEAK
+	}
+
+	return MATCH_NONE
+}
+
+func keysMatch(a *string, b *string) bool {
+	return a != nil && b != nil && *a != "" && *a == *b
+}
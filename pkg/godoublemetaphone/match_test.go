@@ -0,0 +1,149 @@
+package godoublemetaphone
+
+import (
+	"testing"
+)
+
+func TestCompareWords(t *testing.T) {
+	tests := []struct {
+		name string
+		arg1 string
+		arg2 string
+		want MatchLevel
+	}{
+		{
+			name: "test tolled",
+			arg1: "tolled",
+			arg2: "told",
+			want: MATCH_STRONG,
+		},
+		{
+			name: "test katherine",
+			arg1: "katherine",
+			arg2: "catherine",
+			want: MATCH_STRONG,
+		},
+		{
+			name: "test brian",
+			arg1: "brian",
+			arg2: "bryan",
+			want: MATCH_STRONG,
+		},
+		{
+			name: "test Bartosz Bartosch",
+			arg1: "Bartosz",
+			arg2: "Bartosch",
+			want: MATCH_NORMAL,
+		},
+		{
+			name: "test Bartosz Bartos",
+			arg1: "Bartosz",
+			arg2: "Bartos",
+			want: MATCH_STRONG,
+		},
+		{
+			name: "test Jablonski",
+			arg1: "Jablonski",
+			arg2: "Yablonsky",
+			want: MATCH_NORMAL,
+		},
+		{
+			name: "test Smith",
+			arg1: "Smith",
+			arg2: "Schmidt",
+			want: MATCH_NORMAL,
+		},
+		{
+			name: "test Schmidt Smith",
+			arg1: "Schmidt",
+			arg2: "Smith",
+			want: MATCH_NORMAL,
+		},
+		{
+			name: "test Wewski",
+			arg1: "Wewski",
+			arg2: "Vewski",
+			want: MATCH_WEAK,
+		},
+		{
+			name: "test none",
+			arg1: "Peace",
+			arg2: "Jones",
+			want: MATCH_NONE,
+		},
+		{
+			name: "test empty",
+			arg1: "",
+			arg2: "123",
+			want: MATCH_NONE,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareWords(tt.arg1, tt.arg2); got != tt.want {
+				t.Errorf("TestCompareWords = %s, want %s", got, tt.want)
+			}
+			if got := Compare(NewDoubleMetaphone(tt.arg2), NewDoubleMetaphone(tt.arg1)); got != tt.want {
+				t.Errorf("TestCompareWords reversed = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareMatchRating(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want MatchRating
+	}{
+		{
+			name: "test Byrne Boern",
+			a:    "Byrne",
+			b:    "Boern",
+			want: MatchRating{CodexA: "BYRN", CodexB: "BRN", Rating: 5, MinimumRating: 4, Match: true},
+		},
+		{
+			name: "test Smith Smyth",
+			a:    "Smith",
+			b:    "Smyth",
+			want: MatchRating{CodexA: "SMTH", CodexB: "SMYTH", Rating: 5, MinimumRating: 3, Match: true},
+		},
+		{
+			name: "test Catherine Kathryn",
+			a:    "Catherine",
+			b:    "Kathryn",
+			want: MatchRating{CodexA: "CTHRN", CodexB: "KTHRYN", Rating: 4, MinimumRating: 3, Match: true},
+		},
+		{
+			name: "test Christopher Kristoffer",
+			a:    "Christopher",
+			b:    "Kristoffer",
+			want: MatchRating{CodexA: "CHRPHR", CodexB: "KRSTFR", Rating: 1, MinimumRating: 2, Match: false},
+		},
+		{
+			name: "test Smith Jones",
+			a:    "Smith",
+			b:    "Jones",
+			want: MatchRating{CodexA: "SMTH", CodexB: "JNS", Rating: 2, MinimumRating: 4, Match: false},
+		},
+		{
+			name: "test length difference",
+			a:    "Franklin",
+			b:    "Fr",
+			want: MatchRating{CodexA: "FRNKLN", CodexB: "FR", Rating: 0, MinimumRating: 3, Match: false},
+		},
+		{
+			name: "test empty",
+			a:    "",
+			b:    "Smith",
+			want: MatchRating{CodexB: "SMTH"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareMatchRating(tt.a, tt.b); got != tt.want {
+				t.Errorf("TestCompareMatchRating = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
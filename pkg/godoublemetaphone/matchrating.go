@@ -0,0 +1,51 @@
+package godoublemetaphone
+
+import (
+	"strings"
+)
+
+/**
+ * matchrating.go
+ *
+ * Codex of the Match Rating Approach (MRA), developed by Western Airlines in 1977 for
+ * matching passenger names.  Names are compared by their codices with
+ * CompareMatchRating.  Accented letters are folded to their base letters before coding.
+ */
+
+const (
+	MATCH_RATING_CODEX_LENGTH = 6 //Maximum length of a Match Rating Approach codex
+)
+
+/// <summary>Computes the Match Rating Approach codex of a name: its letters without vowels
+///     except a leading one, with doubled consonants coded once, reduced to the first and
+///     last three letters if longer than MATCH_RATING_CODEX_LENGTH</summary>
+///
+/// <returns>The codex, or an empty string if the name has no letters</returns>
+func MatchRatingCodex(word string) string {
+	letters := asciiUpperLetters(FoldWord(word, FOLD_COMPOSE|FOLD_LIGATURES|FOLD_STRIP_DIACRITICS))
+
+	codex := make([]byte, 0, len(letters))
+	for idx, letter := range letters {
+		if idx > 0 && strings.IndexByte("AEIOU", letter) >= 0 {
+			continue
+		}
+		if len(codex) > 0 && codex[len(codex)-1] == letter && strings.IndexByte("AEIOU", letter) < 0 {
+			continue
+		}
+		codex = append(codex, letter)
+	}
+
+	if len(codex) > MATCH_RATING_CODEX_LENGTH {
+		codex = append(codex[:3], codex[len(codex)-3:]...)
+	}
+
+	return string(codex)
+}
+
+/// MatchRatingEncoder is the PhoneticEncoder for the Match Rating Approach codex.  An index
+/// or CompareWith only finds identical codices; use CompareMatchRating to rate a pair
+type MatchRatingEncoder struct{}
+
+func (MatchRatingEncoder) Keys(word string) []string {
+	return []string{MatchRatingCodex(word)}
+}
@@ -0,0 +1,56 @@
+package godoublemetaphone
+
+import (
+	"testing"
+)
+
+func TestMatchRatingCodex(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{
+			name: "test Byrne",
+			arg:  "Byrne",
+			want: "BYRN",
+		},
+		{
+			name: "test Boern",
+			arg:  "Boern",
+			want: "BRN",
+		},
+		{
+			name: "test leading vowel kept",
+			arg:  "Abigail",
+			want: "ABGL",
+		},
+		{
+			name: "test double consonants",
+			arg:  "Mohammed",
+			want: "MHMD",
+		},
+		{
+			name: "test first and last three",
+			arg:  "Christopher",
+			want: "CHRPHR",
+		},
+		{
+			name: "test accents and punctuation",
+			arg:  "O'Šullivan",
+			want: "OSLVN",
+		},
+		{
+			name: "test no letters",
+			arg:  "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchRatingCodex(tt.arg); got != tt.want {
+				t.Errorf("TestMatchRatingCodex = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
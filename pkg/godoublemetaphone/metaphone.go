@@ -0,0 +1,308 @@
+package godoublemetaphone
+
+import (
+	"math"
+)
+
+/**
+ * metaphone.go
+ *
+ * An implementation of Lawrence Phillips' original Metaphone phonetic matching algorithm,
+ * published in Computer Language, December, 1990.  Double Metaphone supersedes it, but
+ * data keyed with Metaphone can be migrated and cross-matched with this implementation.
+ *
+ * The rules follow the widely used Apache Commons Codec implementation, except that an
+ * initial 'CH' before a consonant gives 'K' as Phillips describes.  The word is prepared
+ * (folded, upper cased, padded) exactly as for Double Metaphone.
+ */
+
+type Metaphone interface {
+	Key() string
+	Word() string
+}
+
+type metaphone struct {
+	maxKeyLength int
+	folding      Folding
+
+	///Working copy of the word as runes, and its length without padding
+	word   []rune
+	length int
+
+	key          []byte
+	keyString    string
+	originalWord string
+}
+
+func NewMetaphone(word string) Metaphone {
+	return newMetaphone(word, math.MaxInt64, FOLD_NONE)
+}
+
+func NewMetaphoneLimit(word string, maxKeyLength int) Metaphone {
+	return newMetaphone(word, keyLengthOrUnlimited(maxKeyLength), FOLD_NONE)
+}
+
+/// <summary>Computes the metaphone key after applying the given Unicode folding (a
+///     combination of FOLD_* flags) to the word</summary>
+func NewMetaphoneFolding(word string, folding Folding) Metaphone {
+	return newMetaphone(word, math.MaxInt64, folding)
+}
+
+/// <summary>Computes the metaphone key, limited to maxKeyLength, after applying the given
+///     Unicode folding (a combination of FOLD_* flags) to the word</summary>
+func NewMetaphoneLimitFolding(word string, maxKeyLength int, folding Folding) Metaphone {
+	return newMetaphone(word, keyLengthOrUnlimited(maxKeyLength), folding)
+}
+
+func newMetaphone(word string, maxKeyLength int, folding Folding) *metaphone {
+	mp := &metaphone{
+		maxKeyLength: maxKeyLength,
+		folding:      folding,
+		originalWord: word,
+	}
+
+	mp.word, mp.length = prepareWord(nil, word, folding)
+	mp.buildKey()
+	mp.keyString = string(mp.key)
+
+	return mp
+}
+
+/// <summary>The metaphone key for the word</summary>
+func (mp *metaphone) Key() string {
+	return mp.keyString
+}
+
+/// <summary>Original word for which the key was computed</summary>
+func (mp *metaphone) Word() string {
+	return mp.originalWord
+}
+
+/**
+* Internal impl of the metaphone algorithm.  Populates mp.key
+ */
+func (mp *metaphone) buildKey() {
+	if mp.length == 0 {
+		return
+	}
+
+	if mp.length == 1 {
+		if isMetaphoneLetter(mp.word[0]) {
+			mp.addCharacter(byte(mp.word[0]))
+		}
+		mp.truncate()
+		return
+	}
+
+	//initial letter exceptions, dropping or replacing the first letter
+	switch {
+	case mp.areStringsAt(0, 2, "KN", "GN", "PN", "AE", "WR"):
+		mp.word = mp.word[1:]
+		mp.length--
+	case mp.areStringsAt(0, 2, "WH"):
+		mp.word[1] = 'W'
+		mp.word = mp.word[1:]
+		mp.length--
+	case mp.word[0] == 'X':
+		mp.word[0] = 'S'
+	}
+
+	for current := 0; current < mp.length && len(mp.key) < mp.maxKeyLength; current++ {
+		symbol := mp.word[current]
+
+		//remove duplicate letters, except C
+		if symbol != 'C' && current > 0 && mp.word[current-1] == symbol {
+			continue
+		}
+
+		switch symbol {
+		case 'A', 'E', 'I', 'O', 'U':
+			//vowels are only kept at the start of the word
+			if current == 0 {
+				mp.addCharacter(byte(symbol))
+			}
+
+		case 'B':
+			//silent in '-mb', e.g. 'dumb'
+			if !(mp.isPrevious(current, 'M') && current == mp.length-1) {
+				mp.addCharacter('B')
+			}
+
+		case 'C':
+			//silent in 'sci', 'sce', 'scy'
+			if mp.isPrevious(current, 'S') && mp.isFrontVowel(current+1) {
+				break
+			}
+			if mp.areStringsAt(current, 3, "CIA") {
+				mp.addCharacter('X')
+			} else if mp.isFrontVowel(current + 1) {
+				mp.addCharacter('S')
+			} else if mp.isPrevious(current, 'S') && mp.word[current+1] == 'H' {
+				//'sch'
+				mp.addCharacter('K')
+			} else if mp.word[current+1] == 'H' {
+				//initial 'ch' before a consonant, 'christ', 'chrome' but 'church'
+				if current == 0 && mp.length >= 3 && !mp.isVowel(2) {
+					mp.addCharacter('K')
+				} else {
+					mp.addCharacter('X')
+				}
+			} else {
+				mp.addCharacter('K')
+			}
+
+		case 'D':
+			//'edge', 'dodgy'
+			if current+2 < mp.length && mp.word[current+1] == 'G' && mp.isFrontVowel(current+2) {
+				mp.addCharacter('J')
+				current += 2
+			} else {
+				mp.addCharacter('T')
+			}
+
+		case 'G':
+			//silent in '-gh' at the end and 'gh' not before a vowel, e.g. 'night'
+			if mp.word[current+1] == 'H' && (current+2 == mp.length || !mp.isVowel(current+2)) {
+				break
+			}
+			//silent in 'gn' after the start, e.g. 'sign', 'signed'
+			if current > 0 && mp.areStringsAt(current, 2, "GN") {
+				break
+			}
+			if mp.isFrontVowel(current+1) && !mp.isPrevious(current, 'G') {
+				mp.addCharacter('J')
+			} else {
+				mp.addCharacter('K')
+			}
+
+		case 'H':
+			//silent at the end, after 'c', 's', 'p', 't', 'g', and when not before a vowel
+			if current == mp.length-1 {
+				break
+			}
+			if current > 0 && mp.areStringsAt(current-1, 1, "C", "S", "P", "T", "G") {
+				break
+			}
+			if mp.isVowel(current + 1) {
+				mp.addCharacter('H')
+			}
+
+		case 'F', 'J', 'L', 'M', 'N', 'R':
+			mp.addCharacter(byte(symbol))
+
+		case 'K':
+			//silent after 'c'
+			if !mp.isPrevious(current, 'C') {
+				mp.addCharacter('K')
+			}
+
+		case 'P':
+			if mp.word[current+1] == 'H' {
+				mp.addCharacter('F')
+			} else {
+				mp.addCharacter('P')
+			}
+
+		case 'Q':
+			mp.addCharacter('K')
+
+		case 'S':
+			if mp.areStringsAt(current, 2, "SH") || mp.areStringsAt(current, 3, "SIO", "SIA") {
+				mp.addCharacter('X')
+			} else {
+				mp.addCharacter('S')
+			}
+
+		case 'T':
+			if mp.areStringsAt(current, 3, "TIA", "TIO") {
+				mp.addCharacter('X')
+			} else if mp.areStringsAt(current, 3, "TCH") {
+				//silent, the 'CH' gives 'X'
+			} else if mp.word[current+1] == 'H' {
+				mp.addCharacter('0')
+			} else {
+				mp.addCharacter('T')
+			}
+
+		case 'V':
+			mp.addCharacter('F')
+
+		case 'W', 'Y':
+			//only kept before a vowel
+			if current < mp.length-1 && mp.isVowel(current+1) {
+				mp.addCharacter(byte(symbol))
+			}
+
+		case 'X':
+			mp.addCharacter('K')
+			mp.addCharacter('S')
+
+		case 'Z':
+			mp.addCharacter('S')
+		}
+	}
+
+	mp.truncate()
+}
+
+func (mp *metaphone) addCharacter(character byte) {
+	mp.key = append(mp.key, character)
+}
+
+/// <summary>Chops off the key at the proscribed length</summary>
+func (mp *metaphone) truncate() {
+	if len(mp.key) > mp.maxKeyLength {
+		mp.key = mp.key[:mp.maxKeyLength]
+	}
+}
+
+func (mp *metaphone) isPrevious(pos int, letter rune) bool {
+	return pos > 0 && mp.word[pos-1] == letter
+}
+
+/// <summary>True if the letter at pos is a vowel in Metaphone, where Y is not a vowel</summary>
+func (mp *metaphone) isVowel(pos int) bool {
+	if pos < 0 || pos >= mp.length {
+		return false
+	}
+
+	switch mp.word[pos] {
+	case 'A', 'E', 'I', 'O', 'U':
+		return true
+	}
+
+	return false
+}
+
+/// <summary>True if the letter at pos is E, I or Y, which soften a preceding C or G</summary>
+func (mp *metaphone) isFrontVowel(pos int) bool {
+	if pos < 0 || pos >= mp.length {
+		return false
+	}
+
+	switch mp.word[pos] {
+	case 'E', 'I', 'Y':
+		return true
+	}
+
+	return false
+}
+
+func (mp *metaphone) areStringsAt(start int, length int, strs ...string) bool {
+	if start < 0 || start+length > len(mp.word) {
+		return false
+	}
+
+	target := mp.word[start : start+length]
+	for idx := 0; idx < len(strs); idx++ {
+		if runesEqualString(target, strs[idx]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isMetaphoneLetter(r rune) bool {
+	return r >= 'A' && r <= 'Z'
+}
@@ -0,0 +1,199 @@
+package godoublemetaphone
+
+import (
+	"testing"
+)
+
+func TestMetaphone(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{
+			name: "test howl",
+			arg:  "howl",
+			want: "HL",
+		},
+		{
+			name: "test testing",
+			arg:  "testing",
+			want: "TSTNK",
+		},
+		{
+			name: "test The",
+			arg:  "The",
+			want: "0",
+		},
+		{
+			name: "test quick",
+			arg:  "quick",
+			want: "KK",
+		},
+		{
+			name: "test brown",
+			arg:  "brown",
+			want: "BRN",
+		},
+		{
+			name: "test fox",
+			arg:  "fox",
+			want: "FKS",
+		},
+		{
+			name: "test jumped",
+			arg:  "jumped",
+			want: "JMPT",
+		},
+		{
+			name: "test over",
+			arg:  "over",
+			want: "OFR",
+		},
+		{
+			name: "test lazy",
+			arg:  "lazy",
+			want: "LS",
+		},
+		{
+			name: "test dogs",
+			arg:  "dogs",
+			want: "TKS",
+		},
+		{
+			name: "test Thumb",
+			arg:  "Thumb",
+			want: "0M",
+		},
+		{
+			name: "test Knight",
+			arg:  "Knight",
+			want: "NT",
+		},
+		{
+			name: "test White",
+			arg:  "White",
+			want: "WT",
+		},
+		{
+			name: "test Wright",
+			arg:  "Wright",
+			want: "RT",
+		},
+		{
+			name: "test Xavier",
+			arg:  "Xavier",
+			want: "SFR",
+		},
+		{
+			name: "test Aeneas",
+			arg:  "Aeneas",
+			want: "ENS",
+		},
+		{
+			name: "test Christine",
+			arg:  "Christine",
+			want: "KRSTN",
+		},
+		{
+			name: "test church",
+			arg:  "church",
+			want: "XRX",
+		},
+		{
+			name: "test science",
+			arg:  "science",
+			want: "SNS",
+		},
+		{
+			name: "test edge",
+			arg:  "edge",
+			want: "EJ",
+		},
+		{
+			name: "test signed",
+			arg:  "signed",
+			want: "SNT",
+		},
+		{
+			name: "test Schmidt",
+			arg:  "Schmidt",
+			want: "SKMTT",
+		},
+		{
+			name: "test Michael",
+			arg:  "Michael",
+			want: "MXL",
+		},
+		{
+			name: "test Philip",
+			arg:  "Philip",
+			want: "FLP",
+		},
+		{
+			name: "test Pneumonia",
+			arg:  "Pneumonia",
+			want: "NMN",
+		},
+		{
+			name: "test a",
+			arg:  "a",
+			want: "A",
+		},
+		{
+			name: "test ",
+			arg:  "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMetaphone(tt.arg); got.Key() != tt.want || got.Word() != tt.arg {
+				t.Errorf("TestMetaphone = %s, want %s", got.Key(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMetaphoneLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		arg          string
+		maxKeyLength int
+		want         string
+	}{
+		{
+			name:         "test testing",
+			arg:          "testing",
+			maxKeyLength: 4,
+			want:         "TSTN",
+		},
+		{
+			name:         "test Thompson",
+			arg:          "Thompson",
+			maxKeyLength: 4,
+			want:         "0MPS",
+		},
+		{
+			name:         "test fox",
+			arg:          "fox",
+			maxKeyLength: 2,
+			want:         "FK",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMetaphoneLimit(tt.arg, tt.maxKeyLength); got.Key() != tt.want {
+				t.Errorf("TestMetaphoneLimit = %s, want %s", got.Key(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMetaphoneFolding(t *testing.T) {
+	got := NewMetaphoneFolding("Müller", FOLD_GERMAN)
+	want := NewMetaphone("Mueller")
+	if got.Key() != want.Key() {
+		t.Errorf("TestMetaphoneFolding = %s, want %s", got.Key(), want.Key())
+	}
+}
@@ -0,0 +1,142 @@
+package godoublemetaphone
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+/**
+ * name.go
+ *
+ * Encodes full names word by word.  computeKeys treats a name as one string, so its keys
+ * run across word boundaries; EncodeName splits the name into tokens on white space,
+ * hyphens and apostrophes and computes the keys of each token, plus combined keys for the
+ * whole name.
+ */
+
+/// Particles that NameOptions.KeepParticles attaches to the following token
+var nameParticles = map[string]bool{
+	"AL": true, "BEN": true, "BIN": true, "D": true, "DA": true, "DE": true, "DEL": true, "DELLA": true,
+	"DEN": true, "DER": true, "DI": true, "DU": true, "EL": true, "LA": true, "LE": true, "MAC": true,
+	"MC": true, "O": true, "ST": true, "TEN": true, "TER": true, "VAN": true, "VON": true,
+}
+
+/// NameOptions configures EncodeNameOptions.  The zero value encodes every token on its
+/// own, with unlimited key length and no folding
+type NameOptions struct {
+	///Attach particles such as van, de, von or mac to the following token, so 'van der
+	///Berg' is encoded as one token
+	KeepParticles bool
+
+	///Maximum key length of each token, unlimited when zero or negative
+	MaxKeyLength int
+
+	///Unicode folding applied to each token
+	Folding Folding
+}
+
+/// EncodedName holds the keys of a full name
+type EncodedName struct {
+	///Original name
+	Name string
+
+	///Keys of each token, in name order; Word() is the token
+	Tokens []DoubleMetaphone
+
+	///Primary keys of the tokens separated by spaces, and the same with the alternate key
+	///of each token that has one
+	Primary      string
+	Alternate    string
+	HasAlternate bool
+}
+
+/// <summary>Computes the double metaphone keys of each token of a full name</summary>
+func EncodeName(fullName string) EncodedName {
+	return EncodeNameOptions(fullName, NameOptions{})
+}
+
+/// <summary>Computes the double metaphone keys of each token of a full name, configured
+///     by opts</summary>
+func EncodeNameOptions(fullName string, opts NameOptions) EncodedName {
+	maxKeyLength := opts.MaxKeyLength
+	if maxKeyLength <= 0 {
+		maxKeyLength = math.MaxInt64
+	}
+
+	tokens := TokenizeName(fullName)
+	if opts.KeepParticles {
+		tokens = attachParticles(tokens)
+	}
+
+	name := EncodedName{Name: fullName, Tokens: make([]DoubleMetaphone, 0, len(tokens))}
+	dms := make([]*doubleMetaphone, 0, len(tokens))
+	for _, token := range tokens {
+		dm := newDoubleMetaphone(token, maxKeyLength, opts.Folding)
+		name.Tokens = append(name.Tokens, dm)
+		dms = append(dms, dm)
+	}
+
+	name.Primary, name.Alternate, name.HasAlternate = combineKeys(dms)
+
+	return name
+}
+
+/// <summary>Joins the keys of the tokens of a name with spaces.  The combined alternate key
+///     takes the alternate key of each token that has one, and the primary key of the others.
+///     Empty keys, as of a token of digits, are left out, so they do not add a space</summary>
+///
+/// <returns>The combined primary key, the combined alternate key or an empty string if no
+///     token has an alternate key, and whether any token has an alternate key</returns>
+func combineKeys(tokens []*doubleMetaphone) (string, string, bool) {
+	primaries := make([]string, 0, len(tokens))
+	alternates := make([]string, 0, len(tokens))
+	hasAlternate := false
+	for _, dm := range tokens {
+		alternate := dm.primaryKeyString
+		if dm.hasAlternate {
+			alternate = dm.alternateKeyString
+			hasAlternate = true
+		}
+
+		if dm.primaryKeyString != "" {
+			primaries = append(primaries, dm.primaryKeyString)
+		}
+		if alternate != "" {
+			alternates = append(alternates, alternate)
+		}
+	}
+
+	if !hasAlternate {
+		return strings.Join(primaries, " "), "", false
+	}
+
+	return strings.Join(primaries, " "), strings.Join(alternates, " "), true
+}
+
+/// <summary>Splits a name into tokens on white space, hyphens and apostrophes</summary>
+func TokenizeName(fullName string) []string {
+	return strings.FieldsFunc(fullName, isNameSeparator)
+}
+
+/// <summary>Whether r separates the tokens of a name</summary>
+func isNameSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == '-' || r == '\'' || r == '’' || r == '‐'
+}
+
+/// <summary>Joins each run of particles to the token following it with spaces, so the
+///     rules for e.g. 'VAN ' still apply.  Particles at the end are left alone</summary>
+func attachParticles(tokens []string) []string {
+	attached := make([]string, 0, len(tokens))
+	start := 0
+	for idx, token := range tokens {
+		if nameParticles[strings.ToUpper(token)] && idx < len(tokens)-1 {
+			continue
+		}
+
+		attached = append(attached, strings.Join(tokens[start:idx+1], " "))
+		start = idx + 1
+	}
+
+	return attached
+}
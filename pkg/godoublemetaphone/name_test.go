@@ -0,0 +1,155 @@
+package godoublemetaphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodeName(t *testing.T) {
+	tests := []struct {
+		name             string
+		arg              string
+		opts             NameOptions
+		wantTokens       []string
+		wantPrimary      string
+		wantAlternate    string
+		wantHasAlternate bool
+	}{
+		{
+			name:             "test three words",
+			arg:              "Mary Ann Smith",
+			opts:             NameOptions{},
+			wantTokens:       []string{"Mary", "Ann", "Smith"},
+			wantPrimary:      "MR AN SM0",
+			wantAlternate:    "MR AN XMT",
+			wantHasAlternate: true,
+		},
+		{
+			name:             "test hyphen and apostrophe",
+			arg:              "Mary-Ann O'Brien",
+			opts:             NameOptions{},
+			wantTokens:       []string{"Mary", "Ann", "O", "Brien"},
+			wantPrimary:      "MR AN A PRN",
+			wantAlternate:    "",
+			wantHasAlternate: false,
+		},
+		{
+			name:             "test particle O attached",
+			arg:              "Mary-Ann O'Brien",
+			opts:             NameOptions{KeepParticles: true},
+			wantTokens:       []string{"Mary", "Ann", "O Brien"},
+			wantPrimary:      "MR AN APRN",
+			wantAlternate:    "",
+			wantHasAlternate: false,
+		},
+		{
+			name:             "test particles separate",
+			arg:              "Van der Berg",
+			opts:             NameOptions{},
+			wantTokens:       []string{"Van", "der", "Berg"},
+			wantPrimary:      "FN TR PRK",
+			wantAlternate:    "",
+			wantHasAlternate: false,
+		},
+		{
+			name:             "test particles attached",
+			arg:              "Van der Berg",
+			opts:             NameOptions{KeepParticles: true},
+			wantTokens:       []string{"Van der Berg"},
+			wantPrimary:      "FNTRPRK",
+			wantAlternate:    "",
+			wantHasAlternate: false,
+		},
+		{
+			name:             "test particles attached with alternate",
+			arg:              "Jean-Luc de la Cruz",
+			opts:             NameOptions{KeepParticles: true},
+			wantTokens:       []string{"Jean", "Luc", "de la Cruz"},
+			wantPrimary:      "JN LK TLKRS",
+			wantAlternate:    "AN LK TLKRS",
+			wantHasAlternate: true,
+		},
+		{
+			name:             "test curly apostrophe",
+			arg:              "D’Angelo",
+			opts:             NameOptions{KeepParticles: true},
+			wantTokens:       []string{"D Angelo"},
+			wantPrimary:      "TNJL",
+			wantAlternate:    "TNKL",
+			wantHasAlternate: true,
+		},
+		{
+			name:             "test trailing particle",
+			arg:              "Ludwig van",
+			opts:             NameOptions{KeepParticles: true},
+			wantTokens:       []string{"Ludwig", "van"},
+			wantPrimary:      "LTK FN",
+			wantAlternate:    "",
+			wantHasAlternate: false,
+		},
+		{
+			name:             "test max key length",
+			arg:              "Smith Jones",
+			opts:             NameOptions{MaxKeyLength: 2},
+			wantTokens:       []string{"Smith", "Jones"},
+			wantPrimary:      "SM JN",
+			wantAlternate:    "XM AN",
+			wantHasAlternate: true,
+		},
+		{
+			name:             "test hyphenated surname",
+			arg:              "Catherine Zeta-Jones",
+			opts:             NameOptions{},
+			wantTokens:       []string{"Catherine", "Zeta", "Jones"},
+			wantPrimary:      "K0RN ST JNS",
+			wantAlternate:    "KTRN ST ANS",
+			wantHasAlternate: true,
+		},
+		{
+			name:             "test token without key",
+			arg:              "Smith 12",
+			opts:             NameOptions{},
+			wantTokens:       []string{"Smith", "12"},
+			wantPrimary:      "SM0",
+			wantAlternate:    "XMT",
+			wantHasAlternate: true,
+		},
+		{
+			name:             "test key only from second token",
+			arg:              "12 Smith",
+			opts:             NameOptions{},
+			wantTokens:       []string{"12", "Smith"},
+			wantPrimary:      "SM0",
+			wantAlternate:    "XMT",
+			wantHasAlternate: true,
+		},
+		{
+			name:             "test white space",
+			arg:              "  ",
+			opts:             NameOptions{},
+			wantTokens:       []string{},
+			wantPrimary:      "",
+			wantAlternate:    "",
+			wantHasAlternate: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EncodeNameOptions(tt.arg, tt.opts)
+			tokens := []string{}
+			for _, token := range got.Tokens {
+				tokens = append(tokens, token.Word())
+			}
+			if !reflect.DeepEqual(tokens, tt.wantTokens) {
+				t.Errorf("TestEncodeName tokens = %q, want %q", tokens, tt.wantTokens)
+			}
+			if got.Primary != tt.wantPrimary || got.Alternate != tt.wantAlternate || got.HasAlternate != tt.wantHasAlternate {
+				t.Errorf("TestEncodeName = %q %q %v, want %q %q %v", got.Primary, got.Alternate, got.HasAlternate,
+					tt.wantPrimary, tt.wantAlternate, tt.wantHasAlternate)
+			}
+			if tt.opts == (NameOptions{}) && !reflect.DeepEqual(EncodeName(tt.arg), got) {
+				t.Errorf("TestEncodeName EncodeName = %+v, want %+v", EncodeName(tt.arg), got)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package godoublemetaphone
+
+import (
+	"math"
+	"strings"
+)
+
+/**
+ * nysiis.go
+ *
+ * The New York State Identification and Intelligence System phonetic code (NYSIIS),
+ * published by Robert L. Taft in 1970, and the modified variant of Lynch and Arends.
+ * Codes are traditionally truncated to NYSIIS_KEY_LENGTH characters.
+ *
+ * The original rules follow the Apache Commons Codec implementation.  The modified
+ * variant follows the rules published by Lynch and Arends: it also codes an initial WR,
+ * RH and DG as RR, RR and GG and an initial vowel as A, drops one trailing S or Z, codes
+ * a trailing YE, NT, ND, IX and EX as Y, N, N, ICK and ECK, treats a Y inside the name as
+ * a vowel, codes GHT, DG and WR as TTT, GG and RR, a final SCH and SH as SSA and SA, and
+ * keeps the first letter of a name that starts with a vowel.  The rule that flags a
+ * trailing JR or SR as an error is left out, such names are coded as they are.
+ * Only the letters A-Z are coded; fold a word with FoldWord first to code accented letters.
+ */
+
+const (
+	NYSIIS_KEY_LENGTH = 6 //Traditional length of a NYSIIS code
+)
+
+type Nysiis interface {
+	Key() string
+	Word() string
+}
+
+type nysiis struct {
+	keyString    string
+	originalWord string
+}
+
+/// A replacement of letters at the start or the end of the name
+type nysiisReplacement struct {
+	from string
+	to   string
+}
+
+var nysiisFirstReplacements = []nysiisReplacement{{"MAC", "MCC"}, {"KN", "NN"}, {"K", "C"}, {"PH", "FF"}, {"PF", "FF"}, {"SCH", "SSS"}}
+
+var nysiisLastReplacements = []nysiisReplacement{{"EE", "Y"}, {"IE", "Y"}, {"DT", "D"}, {"RT", "D"}, {"RD", "D"}, {"NT", "D"}, {"ND", "D"}}
+
+var nysiisModifiedFirstReplacements = []nysiisReplacement{{"WR", "RR"}, {"RH", "RR"}, {"DG", "GG"}}
+
+var nysiisModifiedLastReplacements = []nysiisReplacement{{"EE", "Y"}, {"IE", "Y"}, {"YE", "Y"}, {"DT", "D"}, {"RT", "D"}, {"RD", "D"},
+	{"NT", "N"}, {"ND", "N"}, {"IX", "ICK"}, {"EX", "ECK"}}
+
+/// <summary>Computes the original NYSIIS code, not truncated</summary>
+func NewNysiis(word string) Nysiis {
+	return newNysiis(word, math.MaxInt64, false)
+}
+
+/// <summary>Computes the original NYSIIS code, truncated to maxKeyLength characters,
+///     usually NYSIIS_KEY_LENGTH, or not truncated if maxKeyLength is zero or less</summary>
+func NewNysiisLimit(word string, maxKeyLength int) Nysiis {
+	return newNysiis(word, keyLengthOrUnlimited(maxKeyLength), false)
+}
+
+/// <summary>Computes the modified NYSIIS code, not truncated</summary>
+func NewModifiedNysiis(word string) Nysiis {
+	return newNysiis(word, math.MaxInt64, true)
+}
+
+/// <summary>Computes the modified NYSIIS code, truncated to maxKeyLength characters,
+///     usually NYSIIS_KEY_LENGTH, or not truncated if maxKeyLength is zero or less</summary>
+func NewModifiedNysiisLimit(word string, maxKeyLength int) Nysiis {
+	return newNysiis(word, keyLengthOrUnlimited(maxKeyLength), true)
+}
+
+func newNysiis(word string, maxKeyLength int, modified bool) *nysiis {
+	var key string
+	if modified {
+		key = modifiedNysiisKey(string(asciiUpperLetters(word)))
+	} else {
+		key = nysiisKey(string(asciiUpperLetters(word)))
+	}
+	if len(key) > maxKeyLength {
+		key = key[:maxKeyLength]
+	}
+
+	return &nysiis{
+		keyString:    key,
+		originalWord: word,
+	}
+}
+
+/// <summary>The NYSIIS code for the word, empty if it has no letters</summary>
+func (ny *nysiis) Key() string {
+	return ny.keyString
+}
+
+/// <summary>Original word for which the code was computed</summary>
+func (ny *nysiis) Word() string {
+	return ny.originalWord
+}
+
+/// NysiisEncoder is the PhoneticEncoder for NYSIIS.  The zero value computes original
+/// codes of unlimited length
+type NysiisEncoder struct {
+	MaxKeyLength int
+	Modified     bool
+}
+
+func (enc NysiisEncoder) Keys(word string) []string {
+	return []string{newNysiis(word, keyLengthOrUnlimited(enc.MaxKeyLength), enc.Modified).keyString}
+}
+
+/**
+* Internal impl of NYSIIS on the upper case letters of the word
+ */
+func nysiisKey(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	name = replaceNysiisFirst(name, nysiisFirstReplacements)
+	name = replaceNysiisLast(name, nysiisLastReplacements)
+
+	//letters are transcoded in place, so later letters see the transcoded previous letter
+	letters := []byte(name)
+	key := []byte{letters[0]}
+	for current := 1; current < len(letters); current++ {
+		var next, afterNext byte = ' ', ' '
+		if current+1 < len(letters) {
+			next = letters[current+1]
+		}
+		if current+2 < len(letters) {
+			afterNext = letters[current+2]
+		}
+
+		copy(letters[current:], transcodeNysiis(letters[current-1], letters[current], next, afterNext))
+
+		//only add a letter if it differs from the previous one
+		if letters[current] != letters[current-1] {
+			key = append(key, letters[current])
+		}
+	}
+
+	return string(trimNysiisKey(key))
+}
+
+/// <summary>Drops a trailing S, codes a trailing AY as Y and drops a trailing A</summary>
+func trimNysiisKey(key []byte) []byte {
+	if len(key) > 1 {
+		if key[len(key)-1] == 'S' {
+			key = key[:len(key)-1]
+		}
+		if len(key) > 2 && key[len(key)-2] == 'A' && key[len(key)-1] == 'Y' {
+			key = append(key[:len(key)-2], 'Y')
+		}
+		if key[len(key)-1] == 'A' {
+			key = key[:len(key)-1]
+		}
+	}
+
+	return key
+}
+
+/// <summary>The letters replacing current, which may also replace the following letters</summary>
+func transcodeNysiis(previous byte, current byte, next byte, afterNext byte) []byte {
+	if current == 'E' && next == 'V' {
+		return []byte("AF")
+	}
+	if isNysiisVowel(current) {
+		return []byte("A")
+	}
+
+	switch current {
+	case 'Q':
+		return []byte("G")
+	case 'Z':
+		return []byte("S")
+	case 'M':
+		return []byte("N")
+	case 'K':
+		if next == 'N' {
+			return []byte("NN")
+		}
+		return []byte("C")
+	case 'S':
+		if next == 'C' && afterNext == 'H' {
+			return []byte("SSS")
+		}
+	case 'P':
+		if next == 'H' {
+			return []byte("FF")
+		}
+	case 'H':
+		if !isNysiisVowel(previous) || !isNysiisVowel(next) {
+			return []byte{previous}
+		}
+	case 'W':
+		if isNysiisVowel(previous) {
+			return []byte{previous}
+		}
+	}
+
+	return []byte{current}
+}
+
+/**
+* Internal impl of the modified NYSIIS of Lynch and Arends on the upper case letters of
+* the word
+ */
+func modifiedNysiisKey(name string) string {
+	if name == "" {
+		return ""
+	}
+	first := name[0]
+
+	if replaced := replaceNysiisFirst(name, nysiisFirstReplacements); replaced != name {
+		name = replaced
+	} else if replaced := replaceNysiisFirst(name, nysiisModifiedFirstReplacements); replaced != name {
+		name = replaced
+	} else if isNysiisVowel(name[0]) {
+		name = "A" + name[1:]
+	}
+
+	//only one trailing S or Z is dropped
+	if len(name) > 1 && (name[len(name)-1] == 'S' || name[len(name)-1] == 'Z') {
+		name = name[:len(name)-1]
+	}
+	name = replaceNysiisLast(name, nysiisModifiedLastReplacements)
+
+	//letters are transcoded in place, so later letters see the transcoded previous letter;
+	//a replacement of several letters is added to the key as a whole and its letters skipped
+	letters := []byte(name)
+	key := []byte{letters[0]}
+	for current := 1; current < len(letters); {
+		var replacement []byte
+		letters, replacement = transcodeModifiedNysiis(letters, current)
+
+		if len(replacement) > 1 || replacement[0] != key[len(key)-1] {
+			key = append(key, replacement...)
+		}
+		current += len(replacement)
+	}
+
+	key = trimNysiisKey(removeRepeatedLetters(key))
+
+	//trimming can drop every letter, as for OSS, leaving only the first letter
+	if len(key) == 0 {
+		return string(first)
+	}
+
+	//a name that starts with a vowel keeps that vowel
+	if key[0] == 'A' {
+		key[0] = first
+	}
+
+	return string(key)
+}
+
+/// <summary>Transcodes the letter at current by the modified rules</summary>
+///
+/// <returns>letters with the replacement in place, which may be shorter than before, and
+///     the replacement</returns>
+func transcodeModifiedNysiis(letters []byte, current int) ([]byte, []byte) {
+	remaining := len(letters) - current
+	at := func(text string) bool {
+		return strings.HasPrefix(string(letters[current:]), text)
+	}
+	replace := func(length int, replacement string) ([]byte, []byte) {
+		letters = append(letters[:current], append([]byte(replacement), letters[current+length:]...)...)
+		return letters, letters[current : current+len(replacement)]
+	}
+
+	previous := letters[current-1]
+	var next byte = ' '
+	if remaining > 1 {
+		next = letters[current+1]
+	}
+
+	switch {
+	case at("EV"):
+		return replace(2, "AF")
+	case isNysiisVowel(letters[current]):
+		return replace(1, "A")
+	case letters[current] == 'Y' && remaining > 1:
+		return replace(1, "A")
+	case letters[current] == 'Q':
+		return replace(1, "G")
+	case letters[current] == 'Z':
+		return replace(1, "S")
+	case letters[current] == 'M':
+		return replace(1, "N")
+	case at("KN"):
+		return replace(2, "N")
+	case letters[current] == 'K':
+		return replace(1, "C")
+	case at("SCH") && remaining == 3:
+		return replace(3, "SSA")
+	case at("SCH"):
+		return replace(3, "SSS")
+	case at("SH") && remaining == 2:
+		return replace(2, "SA")
+	case at("SH"):
+		return replace(2, "SS")
+	case at("PH"):
+		return replace(2, "FF")
+	case at("GHT"):
+		return replace(3, "TTT")
+	case at("DG"):
+		return replace(2, "GG")
+	case at("WR"):
+		return replace(2, "RR")
+	case letters[current] == 'H' && (!isNysiisVowel(previous) || !isNysiisVowel(next)):
+		return replace(1, string(previous))
+	case letters[current] == 'W' && isNysiisVowel(previous):
+		return replace(1, string(previous))
+	}
+
+	return letters, letters[current : current+1]
+}
+
+/// <summary>Collapses runs of the same letter to one letter</summary>
+func removeRepeatedLetters(key []byte) []byte {
+	collapsed := key[:1]
+	for _, letter := range key[1:] {
+		if letter != collapsed[len(collapsed)-1] {
+			collapsed = append(collapsed, letter)
+		}
+	}
+
+	return collapsed
+}
+
+func isNysiisVowel(letter byte) bool {
+	return letter == 'A' || letter == 'E' || letter == 'I' || letter == 'O' || letter == 'U'
+}
+
+func replaceNysiisFirst(name string, replacements []nysiisReplacement) string {
+	for _, replacement := range replacements {
+		if strings.HasPrefix(name, replacement.from) {
+			return replacement.to + name[len(replacement.from):]
+		}
+	}
+
+	return name
+}
+
+func replaceNysiisLast(name string, replacements []nysiisReplacement) string {
+	for _, replacement := range replacements {
+		if strings.HasSuffix(name, replacement.from) {
+			return name[:len(name)-len(replacement.from)] + replacement.to
+		}
+	}
+
+	return name
+}
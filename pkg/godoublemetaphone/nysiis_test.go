@@ -0,0 +1,387 @@
+package godoublemetaphone
+
+import (
+	"testing"
+)
+
+func TestNysiis(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{
+			name: "test Brian",
+			arg:  "Brian",
+			want: "BRAN",
+		},
+		{
+			name: "test Brown",
+			arg:  "Brown",
+			want: "BRAN",
+		},
+		{
+			name: "test Brownie",
+			arg:  "Brownie",
+			want: "BRANY",
+		},
+		{
+			name: "test Browne",
+			arg:  "Browne",
+			want: "BRAN",
+		},
+		{
+			name: "test Bruin",
+			arg:  "Bruin",
+			want: "BRAN",
+		},
+		{
+			name: "test Capp",
+			arg:  "Capp",
+			want: "CAP",
+		},
+		{
+			name: "test Cope",
+			arg:  "Cope",
+			want: "CAP",
+		},
+		{
+			name: "test Kipp",
+			arg:  "Kipp",
+			want: "CAP",
+		},
+		{
+			name: "test Dane",
+			arg:  "Dane",
+			want: "DAN",
+		},
+		{
+			name: "test Dean",
+			arg:  "Dean",
+			want: "DAN",
+		},
+		{
+			name: "test Dent",
+			arg:  "Dent",
+			want: "DAD",
+		},
+		{
+			name: "test Dionne",
+			arg:  "Dionne",
+			want: "DAN",
+		},
+		{
+			name: "test Smith",
+			arg:  "Smith",
+			want: "SNAT",
+		},
+		{
+			name: "test Schmit",
+			arg:  "Schmit",
+			want: "SNAT",
+		},
+		{
+			name: "test Schmidt",
+			arg:  "Schmidt",
+			want: "SNAD",
+		},
+		{
+			name: "test Trueman",
+			arg:  "Trueman",
+			want: "TRANAN",
+		},
+		{
+			name: "test Truman",
+			arg:  "Truman",
+			want: "TRANAN",
+		},
+		{
+			name: "test Knuth",
+			arg:  "Knuth",
+			want: "NAT",
+		},
+		{
+			name: "test Koehn",
+			arg:  "Koehn",
+			want: "CAN",
+		},
+		{
+			name: "test Phillipson",
+			arg:  "Phillipson",
+			want: "FALAPSAN",
+		},
+		{
+			name: "test Pfeister",
+			arg:  "Pfeister",
+			want: "FASTAR",
+		},
+		{
+			name: "test Schoenhoeft",
+			arg:  "Schoenhoeft",
+			want: "SANAFT",
+		},
+		{
+			name: "test Macintosh",
+			arg:  "Macintosh",
+			want: "MCANT",
+		},
+		{
+			name: "test Mcknight",
+			arg:  "Mcknight",
+			want: "MCNAGT",
+		},
+		{
+			name: "test lower case and punctuation",
+			arg:  "o'daniel",
+			want: "ODANAL",
+		},
+		{
+			name: "test no letters",
+			arg:  "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewNysiis(tt.arg).Key(); got != tt.want {
+				t.Errorf("TestNysiis = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// Reference codes of the modified NYSIIS rules published by Lynch and Arends
+func TestModifiedNysiis(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{
+			name: "test Dent NT coded as N",
+			arg:  "Dent",
+			want: "DAN",
+		},
+		{
+			name: "test Daves one trailing S dropped",
+			arg:  "Daves",
+			want: "DAV",
+		},
+		{
+			name: "test Davies trailing IE",
+			arg:  "Davies",
+			want: "DAVY",
+		},
+		{
+			name: "test Devies EV",
+			arg:  "Devies",
+			want: "DAFY",
+		},
+		{
+			name: "test Divish final SH",
+			arg:  "Divish",
+			want: "DAVAS",
+		},
+		{
+			name: "test Dove",
+			arg:  "Dove",
+			want: "DAV",
+		},
+		{
+			name: "test Devese",
+			arg:  "Devese",
+			want: "DAFAS",
+		},
+		{
+			name: "test Devos",
+			arg:  "Devos",
+			want: "DAF",
+		},
+		{
+			name: "test Schmit initial SCH",
+			arg:  "Schmit",
+			want: "SNAT",
+		},
+		{
+			name: "test Schmitz one trailing Z dropped",
+			arg:  "Schmitz",
+			want: "SNAT",
+		},
+		{
+			name: "test Schmoutz",
+			arg:  "Schmoutz",
+			want: "SNAT",
+		},
+		{
+			name: "test Schnitt",
+			arg:  "Schnitt",
+			want: "SNAT",
+		},
+		{
+			name: "test Staats",
+			arg:  "Staats",
+			want: "STAT",
+		},
+		{
+			name: "test Stutz",
+			arg:  "Stutz",
+			want: "STAT",
+		},
+		{
+			name: "test Tabler",
+			arg:  "Tabler",
+			want: "TABLAR",
+		},
+		{
+			name: "test Tayler inner Y",
+			arg:  "Tayler",
+			want: "TALAR",
+		},
+		{
+			name: "test Taylor",
+			arg:  "Taylor",
+			want: "TALAR",
+		},
+		{
+			name: "test Tiegues",
+			arg:  "Tiegues",
+			want: "TAG",
+		},
+		{
+			name: "test Stuckey final Y kept",
+			arg:  "Stuckey",
+			want: "STACY",
+		},
+		{
+			name: "test Felix trailing IX",
+			arg:  "Felix",
+			want: "FALAC",
+		},
+		{
+			name: "test Wright initial WR and GHT",
+			arg:  "Wright",
+			want: "RAT",
+		},
+		{
+			name: "test Rhodes initial RH",
+			arg:  "Rhodes",
+			want: "RAD",
+		},
+		{
+			name: "test Hodge DG",
+			arg:  "Hodge",
+			want: "HAG",
+		},
+		{
+			name: "test Edwards initial vowel kept",
+			arg:  "Edwards",
+			want: "EDWAD",
+		},
+		{
+			name: "test Fisch final SCH",
+			arg:  "Fisch",
+			want: "FAS",
+		},
+		{
+			name: "test Bush",
+			arg:  "Bush",
+			want: "BAS",
+		},
+		{
+			name: "test Ssss trailing S of only S",
+			arg:  "Ssss",
+			want: "S",
+		},
+		{
+			name: "test Oss trimmed to first letter",
+			arg:  "Oss",
+			want: "O",
+		},
+		{
+			name: "test Ass trimmed to first letter",
+			arg:  "Ass",
+			want: "A",
+		},
+		{
+			name: "test ASZ trimmed to first letter",
+			arg:  "ASZ",
+			want: "A",
+		},
+		{
+			name: "test 'AzH trimmed to first letter",
+			arg:  "'AzH",
+			want: "A",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewModifiedNysiis(tt.arg).Key(); got != tt.want {
+				t.Errorf("TestModifiedNysiis = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNysiisLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		nysiis  Nysiis
+		want    string
+		wantArg string
+	}{
+		{
+			name:    "test original limited",
+			nysiis:  NewNysiisLimit("Phillipson", NYSIIS_KEY_LENGTH),
+			want:    "FALAPS",
+			wantArg: "Phillipson",
+		},
+		{
+			name:    "test modified limited",
+			nysiis:  NewModifiedNysiisLimit("Stutzman", NYSIIS_KEY_LENGTH),
+			want:    "STATSN",
+			wantArg: "Stutzman",
+		},
+		{
+			name:    "test shorter than limit",
+			nysiis:  NewNysiisLimit("Dean", NYSIIS_KEY_LENGTH),
+			want:    "DAN",
+			wantArg: "Dean",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.nysiis.Key(); got != tt.want || tt.nysiis.Word() != tt.wantArg {
+				t.Errorf("TestNysiisLimit = %s %s, want %s %s", got, tt.nysiis.Word(), tt.want, tt.wantArg)
+			}
+		})
+	}
+}
+
+func TestNysiisEncoder(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoder NysiisEncoder
+		arg     string
+		want    string
+	}{
+		{
+			name:    "test original",
+			encoder: NysiisEncoder{},
+			arg:     "Dent",
+			want:    "DAD",
+		},
+		{
+			name:    "test modified limited",
+			encoder: NysiisEncoder{MaxKeyLength: NYSIIS_KEY_LENGTH, Modified: true},
+			arg:     "Bridges",
+			want:    "BRAG",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.encoder.Keys(tt.arg); len(got) != 1 || got[0] != tt.want {
+				t.Errorf("TestNysiisEncoder = %v, want [%s]", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package godoublemetaphone
+
+import (
+	"math"
+)
+
+/**
+ * options.go
+ *
+ * New computes the keys of a word configured by functional options, so a new setting does
+ * not need yet another NewDoubleMetaphone* constructor.  The existing constructors are
+ * wrappers around New.
+ */
+
+/// Option configures New.  Options take and return the configuration by value, so it stays
+/// on the stack and New allocates no more than newDoubleMetaphone
+type Option func(options) options
+
+type options struct {
+	maxKeyLength int
+	folding      Folding
+	prefixes     PrefixPolicy
+
+	///Use the primary key as the alternate key of a word without one
+	identicalAlternate bool
+
+	///Encode each word of the input on its own
+	multiWord bool
+}
+
+/// <summary>Limits the keys to at most maxKeyLength characters.  Keys are unlimited by
+///     default, or if maxKeyLength is zero or less, as for BatchOptions</summary>
+func WithMaxKeyLength(maxKeyLength int) Option {
+	return func(opts options) options {
+		opts.maxKeyLength = maxKeyLength
+		return opts
+	}
+}
+
+/// <summary>Applies the given Unicode folding to the word.  There is no folding by default</summary>
+func WithFolding(folding Folding) Option {
+	return func(opts options) options {
+		opts.folding = folding
+		return opts
+	}
+}
+
+/// <summary>Rewrites surname prefixes at the start of each word of the input with policy
+///     before the keys are computed.  A DoubleMetaphone holds only two keys, so for
+///     PREFIX_BOTH the keys are those of the fused form, and if it has no alternate key the
+///     primary key of the stripped form becomes the alternate, so "O'Brien" gives APRN and
+///     PRN.  DoubleMetaphoneEncoder with Prefixes gives all keys of both forms</summary>
+func WithPrefixes(policy PrefixPolicy) Option {
+	return func(opts options) options {
+		opts.prefixes = policy
+		return opts
+	}
+}
+
+/// <summary>Selects whether a word without an alternate key gets one identical to the
+///     primary key, as other Double Metaphone ports return, so AlternateKey is never nil.
+///     By default AlternateKey returns nil for such a word, as NewDoubleMetaphone does</summary>
+func WithIdenticalAlternate(emit bool) Option {
+	return func(opts options) options {
+		opts.identicalAlternate = emit
+		return opts
+	}
+}
+
+/// <summary>Treats the input as several words, split as by TokenizeName.  Each word is
+///     encoded on its own and the keys are joined with spaces, as by EncodeName; the key
+///     length limit applies to each word.  By default the input is encoded as one word, and
+///     the keys run across spaces</summary>
+func WithMultiWord(multiWord bool) Option {
+	return func(opts options) options {
+		opts.multiWord = multiWord
+		return opts
+	}
+}
+
+/// <summary>Computes the double metaphone keys of word, configured by opts</summary>
+func New(word string, opts ...Option) DoubleMetaphone {
+	return newOptions(opts).metaphone(word)
+}
+
+/// <summary>Applies opts to the default configuration</summary>
+func newOptions(opts []Option) options {
+	config := options{
+		maxKeyLength: math.MaxInt64,
+		folding:      FOLD_NONE,
+	}
+	for _, opt := range opts {
+		config = opt(config)
+	}
+	config.maxKeyLength = keyLengthOrUnlimited(config.maxKeyLength)
+
+	return config
+}
+
+/// <summary>Computes the double metaphone keys of word as configured</summary>
+func (config options) metaphone(word string) *doubleMetaphone {
+	var dm *doubleMetaphone
+	if config.prefixes == nil {
+		dm = config.encode(word)
+	} else {
+		fused := config.prefixes.rewriteWords(word, false)
+		dm = config.encode(fused)
+		dm.originalWord = word
+
+		if stripped := config.prefixes.rewriteWords(word, true); !dm.hasAlternate && stripped != fused {
+			if alternate := config.encode(stripped).primaryKeyString; alternate != dm.primaryKeyString {
+				dm.alternateKeyString = alternate
+				dm.hasAlternate = true
+			}
+		}
+	}
+
+	if config.identicalAlternate && !dm.hasAlternate {
+		dm.alternateKeyString = dm.primaryKeyString
+		dm.hasAlternate = true
+	}
+
+	return dm
+}
+
+/// <summary>Computes the keys of word as one word or, for multi-word input, of each of its
+///     words</summary>
+func (config options) encode(word string) *doubleMetaphone {
+	if !config.multiWord {
+		return newDoubleMetaphone(word, config.maxKeyLength, config.folding)
+	}
+
+	tokens := TokenizeName(word)
+	dms := make([]*doubleMetaphone, 0, len(tokens))
+	for _, token := range tokens {
+		dms = append(dms, newDoubleMetaphone(token, config.maxKeyLength, config.folding))
+	}
+
+	dm := &doubleMetaphone{
+		maxKeyLength: config.maxKeyLength,
+		folding:      config.folding,
+		originalWord: word,
+	}
+	dm.primaryKeyString, dm.alternateKeyString, dm.hasAlternate = combineKeys(dms)
+
+	return dm
+}
@@ -0,0 +1,153 @@
+package godoublemetaphone
+
+import (
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name          string
+		word          string
+		opts          []Option
+		wantPrimary   string
+		wantAlternate *string
+	}{
+		{name: "test defaults", word: "Smith", wantPrimary: "SM0", wantAlternate: stringPtr("XMT")},
+		{name: "test max key length", word: "Jankelowicz", opts: []Option{WithMaxKeyLength(4)}, wantPrimary: "JNKL", wantAlternate: stringPtr("ANKL")},
+		{name: "test folding", word: "Müller", opts: []Option{WithFolding(FOLD_ALL)}, wantPrimary: NewDoubleMetaphone("Mueller").PrimaryKey()},
+		{name: "test no alternate", word: "Thomas", wantPrimary: "TMS"},
+		{name: "test identical alternate", word: "Thomas", opts: []Option{WithIdenticalAlternate(true)}, wantPrimary: "TMS", wantAlternate: stringPtr("TMS")},
+		{name: "test identical alternate keeps alternate", word: "Michael", opts: []Option{WithIdenticalAlternate(true)}, wantPrimary: "MKL", wantAlternate: stringPtr("MXL")},
+		{name: "test single word", word: "Mary Smith", wantPrimary: "MRSM0", wantAlternate: stringPtr("MRSMT")},
+		{name: "test multi word", word: "Mary Smith", opts: []Option{WithMultiWord(true)}, wantPrimary: "MR SM0", wantAlternate: stringPtr("MR XMT")},
+		{name: "test multi word limit per word", word: "Mary-Ann Smith", opts: []Option{WithMultiWord(true), WithMaxKeyLength(1)}, wantPrimary: "M A S", wantAlternate: stringPtr("M A X")},
+		{name: "test prefix fuse", word: "O'Brien", opts: []Option{WithPrefixes(PrefixPolicy{{Prefix: "O", Action: PREFIX_FUSE}}), WithMultiWord(true)}, wantPrimary: "APRN"},
+		{name: "test prefix both", word: "De la Cruz", opts: []Option{WithPrefixes(DefaultPrefixPolicy())}, wantPrimary: "TLKRS", wantAlternate: stringPtr("KRS")},
+		{name: "test prefix both multi word", word: "De la Cruz Smith", opts: []Option{WithPrefixes(DefaultPrefixPolicy()), WithMultiWord(true)}, wantPrimary: "TLKRS SM0", wantAlternate: stringPtr("TLKRS XMT")},
+		{name: "test prefix both stripped primary as alternate", word: "Van Wagner", opts: []Option{WithPrefixes(DefaultPrefixPolicy())}, wantPrimary: "FNKNR", wantAlternate: stringPtr("AKNR")},
+		{name: "test prefix both single word", word: "O'Brien", opts: []Option{WithPrefixes(DefaultPrefixPolicy())}, wantPrimary: "APRN", wantAlternate: stringPtr("PRN")},
+		{name: "test prefix both keeps alternate of fused form", word: "D'Angelo", opts: []Option{WithPrefixes(DefaultPrefixPolicy())}, wantPrimary: "TNJL", wantAlternate: stringPtr("TNKL")},
+		{name: "test prefix on second word", word: "Sean O'Brien", opts: []Option{WithPrefixes(DefaultPrefixPolicy()), WithMultiWord(true)}, wantPrimary: "SN APRN", wantAlternate: stringPtr("SN PRN")},
+		{name: "test prefix on first word", word: "O'Brien Sean", opts: []Option{WithPrefixes(DefaultPrefixPolicy()), WithMultiWord(true)}, wantPrimary: "APRN SN", wantAlternate: stringPtr("PRN SN")},
+		{name: "test prefix strip on second word", word: "Sean O'Brien", opts: []Option{WithPrefixes(PrefixPolicy{{Prefix: "O", Action: PREFIX_STRIP}}), WithMultiWord(true)}, wantPrimary: "SN PRN"},
+		{name: "test prefix multi word no letters", word: "Smith 12", opts: []Option{WithPrefixes(DefaultPrefixPolicy()), WithMultiWord(true)}, wantPrimary: "SM0", wantAlternate: stringPtr("XMT")},
+		{name: "test zero max key length is unlimited", word: "Xavier", opts: []Option{WithMaxKeyLength(0)}, wantPrimary: "SF", wantAlternate: stringPtr("SFR")},
+		{name: "test negative max key length is unlimited", word: "Xavier", opts: []Option{WithMaxKeyLength(-1)}, wantPrimary: "SF", wantAlternate: stringPtr("SFR")},
+		{name: "test empty multi word", word: " ", opts: []Option{WithMultiWord(true)}, wantPrimary: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(tt.word, tt.opts...)
+			if got.PrimaryKey() != tt.wantPrimary || !compareStringPointers(got.AlternateKey(), tt.wantAlternate) {
+				t.Errorf("New(%q) = %v/%v, want %v/%v", tt.word, got.PrimaryKey(), safeString(got.AlternateKey()), tt.wantPrimary, safeString(tt.wantAlternate))
+			}
+			if got.Word() != tt.word {
+				t.Errorf("New(%q).Word() = %q", tt.word, got.Word())
+			}
+		})
+	}
+}
+
+func TestNewPrefixMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		opts []Option
+		want MatchLevel
+	}{
+		{
+			name: "test O'Brien Brien",
+			a:    "O'Brien",
+			b:    "Brien",
+			opts: []Option{WithPrefixes(DefaultPrefixPolicy())},
+			want: MATCH_NORMAL,
+		},
+		{
+			name: "test O'Brien Brien without prefixes",
+			a:    "O'Brien",
+			b:    "Brien",
+			want: MATCH_NONE,
+		},
+		{
+			name: "test Van Wagner Wagner",
+			a:    "Van Wagner",
+			b:    "Wagner",
+			opts: []Option{WithPrefixes(DefaultPrefixPolicy())},
+			want: MATCH_NORMAL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compare(New(tt.a, tt.opts...), New(tt.b, tt.opts...)); got != tt.want {
+				t.Errorf("TestNewPrefixMatch = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMatchesConstructors(t *testing.T) {
+	for _, word := range []string{"Smith", "Jankelowicz", "Müller", "Ghislane", ""} {
+		checks := []struct {
+			name string
+			want DoubleMetaphone
+			got  DoubleMetaphone
+		}{
+			{"NewDoubleMetaphone", NewDoubleMetaphone(word), New(word)},
+			{"NewDoubleMetaphoneLimit", NewDoubleMetaphoneLimit(word, 3), New(word, WithMaxKeyLength(3))},
+			{"NewDoubleMetaphoneFolding", NewDoubleMetaphoneFolding(word, FOLD_ALL), New(word, WithFolding(FOLD_ALL))},
+			{"NewDoubleMetaphoneLimitFolding", NewDoubleMetaphoneLimitFolding(word, 3, FOLD_ALL), New(word, WithFolding(FOLD_ALL), WithMaxKeyLength(3))},
+		}
+		for _, check := range checks {
+			if check.got.PrimaryKey() != check.want.PrimaryKey() || !compareStringPointers(check.got.AlternateKey(), check.want.AlternateKey()) {
+				t.Errorf("%s(%q) = %v/%v, New = %v/%v", check.name, word, check.want.PrimaryKey(), safeString(check.want.AlternateKey()),
+					check.got.PrimaryKey(), safeString(check.got.AlternateKey()))
+			}
+		}
+	}
+}
+
+func TestLimitConstructorsOutOfRange(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "test double metaphone zero",
+			got:  NewDoubleMetaphoneLimit("Xavier", 0).PrimaryKey(),
+			want: "",
+		},
+		{
+			name: "test double metaphone negative",
+			got:  NewDoubleMetaphoneLimitFolding("Xavier", -1, FOLD_NONE).PrimaryKey(),
+			want: "",
+		},
+		{
+			name: "test nysiis negative",
+			got:  NewNysiisLimit("Xavier", -1).Key(),
+			want: "XAVAR",
+		},
+		{
+			name: "test modified nysiis negative",
+			got:  NewModifiedNysiisLimit("Xavier", -1).Key(),
+			want: "XAVAR",
+		},
+		{
+			name: "test metaphone negative",
+			got:  NewMetaphoneLimit("Xavier", -1).Key(),
+			want: "SFR",
+		},
+		{
+			name: "test spanish negative",
+			got:  NewSpanishPhoneticLimit("Xavier", -1).Key(),
+			want: "JBR",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("TestLimitConstructorsOutOfRange = %s, want %s", tt.got, tt.want)
+			}
+		})
+	}
+}
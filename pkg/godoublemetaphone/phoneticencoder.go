@@ -0,0 +1,89 @@
+package godoublemetaphone
+
+import (
+	"math"
+)
+
+/**
+ * phoneticencoder.go
+ *
+ * Common interface over the phonetic algorithms of this package, so index and comparison
+ * code can swap algorithms without being rewritten.
+ */
+
+/// PhoneticEncoder computes the phonetic keys of a word.  Implementations are safe for
+/// concurrent use
+type PhoneticEncoder interface {
+	/// Keys returns the primary key of word first, followed by any alternate keys
+	Keys(word string) []string
+}
+
+/// DoubleMetaphoneEncoder is the PhoneticEncoder for Double Metaphone.  The zero value
+/// produces keys of unlimited length without folding or prefix handling
+type DoubleMetaphoneEncoder struct {
+	MaxKeyLength int
+	Folding      Folding
+
+	///Surname prefixes rewritten before the keys are computed, see DefaultPrefixPolicy
+	Prefixes PrefixPolicy
+}
+
+/// <summary>The primary key, followed by the alternate key if the word has one.  When the
+///     prefix policy yields more than one form of the word, the keys of each form follow
+///     in turn, without duplicates</summary>
+func (enc DoubleMetaphoneEncoder) Keys(word string) []string {
+	maxKeyLength := keyLengthOrUnlimited(enc.MaxKeyLength)
+	if enc.Prefixes == nil {
+		return newDoubleMetaphone(word, maxKeyLength, enc.Folding).keys(nil)
+	}
+
+	var keys []string
+	for _, form := range enc.Prefixes.Forms(word) {
+		keys = newDoubleMetaphone(form, maxKeyLength, enc.Folding).keys(keys)
+	}
+
+	return keys
+}
+
+/// <summary>Appends the primary key and the alternate key, if any, to keys unless keys
+///     already holds them</summary>
+func (dm *doubleMetaphone) keys(keys []string) []string {
+	keys = appendKey(keys, dm.primaryKeyString)
+	if dm.hasAlternate {
+		keys = appendKey(keys, dm.alternateKeyString)
+	}
+
+	return keys
+}
+
+/// <summary>Appends key to keys unless keys already holds it</summary>
+func appendKey(keys []string, key string) []string {
+	for _, existing := range keys {
+		if existing == key {
+			return keys
+		}
+	}
+
+	return append(keys, key)
+}
+
+/// MetaphoneEncoder is the PhoneticEncoder for the original Metaphone.  The zero value
+/// produces keys of unlimited length without folding
+type MetaphoneEncoder struct {
+	MaxKeyLength int
+	Folding      Folding
+}
+
+/// <summary>The metaphone key</summary>
+func (enc MetaphoneEncoder) Keys(word string) []string {
+	return []string{newMetaphone(word, keyLengthOrUnlimited(enc.MaxKeyLength), enc.Folding).keyString}
+}
+
+/// <summary>Maps a zero or negative key length to unlimited</summary>
+func keyLengthOrUnlimited(maxKeyLength int) int {
+	if maxKeyLength <= 0 {
+		return math.MaxInt64
+	}
+
+	return maxKeyLength
+}
@@ -0,0 +1,186 @@
+package godoublemetaphone
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/**
+ * prefix.go
+ *
+ * Surname prefixes and particles.  A name written with a prefix gets different keys than
+ * the name without it, so "O'Brien" does not match "Brien", nor "De la Cruz" "Cruz".  A
+ * PrefixPolicy rewrites a word before its keys are computed: a prefix can be stripped,
+ * fused to the rest of the name by dropping the separators between them, or both, in
+ * which case the keys of both forms are emitted.
+ *
+ * The double metaphone rules skip separators, so fusing alone changes the keys of a word
+ * only where a rule looks at a separator, as the rules for "VAN " and "VON " do.  It
+ * matters more where a name is split into words, as by New with WithMultiWord, which
+ * would otherwise encode "O'Brien" as the words "O" and "Brien".
+ */
+
+/// PrefixAction selects what a PrefixPolicy does with a prefix
+type PrefixAction int
+
+const (
+	/// Leave the word alone
+	PREFIX_KEEP PrefixAction = iota
+
+	/// Remove the prefix: "O'Brien" -> "Brien"
+	PREFIX_STRIP
+
+	/// Remove the separators after the prefix: "O'Brien" -> "OBrien"
+	PREFIX_FUSE
+
+	/// Encode both the fused and the stripped form and emit the keys of both
+	PREFIX_BOTH
+)
+
+/// NamePrefix is one entry of a PrefixPolicy
+type NamePrefix struct {
+	///Upper case prefix, with a single space between the words of a multi-word prefix
+	///such as "DE LA"
+	Prefix string
+
+	///The prefix may be written without a separator, as in "McDonald".  It then only
+	///matches when the rest of the name starts with an upper case letter
+	Attached bool
+
+	Action PrefixAction
+}
+
+/// PrefixPolicy is an ordered table of prefixes; the first prefix that matches a word is
+/// applied, so longer prefixes go before their shorter forms
+type PrefixPolicy []NamePrefix
+
+/// Characters that separate a prefix from the rest of the name
+const prefixSeparators = " '’-.‐"
+
+/// <summary>The default prefix table.  Prefixes that are often dropped or written apart
+///     (O', D', De la, Van, Von, ...) are encoded both ways, so the name matches with and
+///     without them.  The table is a new copy, so it can be changed by the caller</summary>
+func DefaultPrefixPolicy() PrefixPolicy {
+	return PrefixPolicy{
+		{Prefix: "VAN DER", Action: PREFIX_BOTH},
+		{Prefix: "VAN DEN", Action: PREFIX_BOTH},
+		{Prefix: "VAN DE", Action: PREFIX_BOTH},
+		{Prefix: "VON DER", Action: PREFIX_BOTH},
+		{Prefix: "VAN", Action: PREFIX_BOTH},
+		{Prefix: "VON", Action: PREFIX_BOTH},
+		{Prefix: "DE LA", Action: PREFIX_BOTH},
+		{Prefix: "DE LOS", Action: PREFIX_BOTH},
+		{Prefix: "DE LAS", Action: PREFIX_BOTH},
+		{Prefix: "DE", Action: PREFIX_BOTH},
+		{Prefix: "DEL", Action: PREFIX_BOTH},
+		{Prefix: "DELLA", Action: PREFIX_BOTH},
+		{Prefix: "DI", Action: PREFIX_BOTH},
+		{Prefix: "DA", Action: PREFIX_BOTH},
+		{Prefix: "DU", Action: PREFIX_BOTH},
+		{Prefix: "LA", Action: PREFIX_BOTH},
+		{Prefix: "LE", Action: PREFIX_BOTH},
+		{Prefix: "O", Action: PREFIX_BOTH},
+		{Prefix: "D", Action: PREFIX_BOTH},
+	}
+}
+
+/// <summary>Applies the policy to word</summary>
+///
+/// <returns>The forms of word to encode: word itself when no prefix matches, else one
+///     form, or the fused form followed by the stripped form for PREFIX_BOTH</returns>
+func (policy PrefixPolicy) Forms(word string) []string {
+	for _, prefix := range policy {
+		if prefix.Action == PREFIX_KEEP {
+			continue
+		}
+
+		fused, stripped, ok := prefix.split(word)
+		if !ok {
+			continue
+		}
+
+		switch prefix.Action {
+		case PREFIX_STRIP:
+			return []string{stripped}
+		case PREFIX_FUSE:
+			return []string{fused}
+		default:
+			return []string{fused, stripped}
+		}
+	}
+
+	return []string{word}
+}
+
+/// <summary>Applies the policy at the start of each word of text, as split by TokenizeName,
+///     keeping the first form of each, or the last if last is set, i.e. the stripped form of
+///     a PREFIX_BOTH prefix.  A prefix fused to the following word stays one token, so
+///     multi-word input does not encode "O'Brien" as the words "O" and "Brien"</summary>
+func (policy PrefixPolicy) rewriteWords(text string, last bool) string {
+	pos := 0
+	for pos < len(text) {
+		r, size := utf8.DecodeRuneInString(text[pos:])
+		if isNameSeparator(r) {
+			pos += size
+			continue
+		}
+
+		forms := policy.Forms(text[pos:])
+		if last {
+			text = text[:pos] + forms[len(forms)-1]
+		} else {
+			text = text[:pos] + forms[0]
+		}
+
+		//skip the word, now with any prefix fused to it or stripped
+		for pos < len(text) {
+			r, size := utf8.DecodeRuneInString(text[pos:])
+			if isNameSeparator(r) {
+				break
+			}
+			pos += size
+		}
+	}
+
+	return text
+}
+
+/// <summary>Matches the prefix at the start of word</summary>
+///
+/// <returns>word without the separators after each word of the prefix, word without the
+///     prefix, and whether the prefix matched</returns>
+func (prefix NamePrefix) split(word string) (string, string, bool) {
+	runes := []rune(word)
+	var fused []rune
+	pos := 0
+	parts := strings.Fields(prefix.Prefix)
+	for idx, part := range parts {
+		for _, r := range part {
+			if pos >= len(runes) || unicode.ToUpper(runes[pos]) != r {
+				return "", "", false
+			}
+			fused = append(fused, runes[pos])
+			pos++
+		}
+
+		separated := pos
+		for pos < len(runes) && strings.ContainsRune(prefixSeparators, runes[pos]) {
+			pos++
+		}
+
+		if pos == separated {
+			//Only the last word of an attached prefix can go without a separator
+			if !prefix.Attached || idx < len(parts)-1 || pos >= len(runes) || !unicode.IsUpper(runes[pos]) {
+				return "", "", false
+			}
+		}
+	}
+
+	//There must be a name after the prefix
+	if pos >= len(runes) || !unicode.IsLetter(runes[pos]) {
+		return "", "", false
+	}
+
+	return string(append(fused, runes[pos:]...)), string(runes[pos:]), true
+}
@@ -0,0 +1,247 @@
+package godoublemetaphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPrefixPolicyForms(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want []string
+	}{
+		{
+			name: "test O apostrophe",
+			arg:  "O'Brien",
+			want: []string{"OBrien", "Brien"},
+		},
+		{
+			name: "test O curly apostrophe",
+			arg:  "O’Brien",
+			want: []string{"OBrien", "Brien"},
+		},
+		{
+			name: "test D apostrophe",
+			arg:  "D'Angelo",
+			want: []string{"DAngelo", "Angelo"},
+		},
+		{
+			name: "test De la",
+			arg:  "De la Cruz",
+			want: []string{"DelaCruz", "Cruz"},
+		},
+		{
+			name: "test Van der",
+			arg:  "Van der Berg",
+			want: []string{"VanderBerg", "Berg"},
+		},
+		{
+			name: "test lower case von",
+			arg:  "von Braun",
+			want: []string{"vonBraun", "Braun"},
+		},
+		{
+			name: "test prefix without separator",
+			arg:  "OBrien",
+			want: []string{"OBrien"},
+		},
+		{
+			name: "test name starting like a prefix",
+			arg:  "Dean",
+			want: []string{"Dean"},
+		},
+		{
+			name: "test prefix only",
+			arg:  "De",
+			want: []string{"De"},
+		},
+		{
+			name: "test prefix and separator only",
+			arg:  "O'",
+			want: []string{"O'"},
+		},
+		{
+			name: "test empty",
+			arg:  "",
+			want: []string{""},
+		},
+	}
+	policy := DefaultPrefixPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Forms(tt.arg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestPrefixPolicyForms = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrefixPolicyCustom(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want []string
+	}{
+		{
+			name: "test attached Mac stripped",
+			arg:  "MacDonald",
+			want: []string{"Donald"},
+		},
+		{
+			name: "test Mac followed by lower case",
+			arg:  "Macy",
+			want: []string{"Macy"},
+		},
+		{
+			name: "test kept",
+			arg:  "O'Brien",
+			want: []string{"O'Brien"},
+		},
+		{
+			name: "test St fused",
+			arg:  "St. John",
+			want: []string{"StJohn"},
+		},
+	}
+	policy := PrefixPolicy{
+		{Prefix: "MAC", Attached: true, Action: PREFIX_STRIP},
+		{Prefix: "O", Action: PREFIX_KEEP},
+		{Prefix: "ST", Action: PREFIX_FUSE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Forms(tt.arg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestPrefixPolicyCustom = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrefixPolicyEncoder(t *testing.T) {
+	plain := DoubleMetaphoneEncoder{}
+	prefixed := DoubleMetaphoneEncoder{Prefixes: DefaultPrefixPolicy()}
+	stripped := DoubleMetaphoneEncoder{Prefixes: PrefixPolicy{{Prefix: "MAC", Attached: true, Action: PREFIX_STRIP}}}
+	tests := []struct {
+		name    string
+		encoder DoubleMetaphoneEncoder
+		a       string
+		b       string
+		want    MatchLevel
+	}{
+		{
+			name:    "test O'Brien plain",
+			encoder: plain,
+			a:       "O'Brien",
+			b:       "Brien",
+			want:    MATCH_NONE,
+		},
+		{
+			name:    "test O'Brien prefixed",
+			encoder: prefixed,
+			a:       "O'Brien",
+			b:       "Brien",
+			want:    MATCH_NORMAL,
+		},
+		{
+			name:    "test D'Angelo plain",
+			encoder: plain,
+			a:       "D'Angelo",
+			b:       "Angelo",
+			want:    MATCH_NONE,
+		},
+		{
+			name:    "test D'Angelo prefixed",
+			encoder: prefixed,
+			a:       "D'Angelo",
+			b:       "Angelo",
+			want:    MATCH_NORMAL,
+		},
+		{
+			name:    "test De la Cruz plain",
+			encoder: plain,
+			a:       "De la Cruz",
+			b:       "Cruz",
+			want:    MATCH_NONE,
+		},
+		{
+			name:    "test De la Cruz prefixed",
+			encoder: prefixed,
+			a:       "De la Cruz",
+			b:       "Cruz",
+			want:    MATCH_NORMAL,
+		},
+		{
+			name:    "test Van der Berg plain",
+			encoder: plain,
+			a:       "Van der Berg",
+			b:       "Berg",
+			want:    MATCH_NONE,
+		},
+		{
+			name:    "test Van der Berg prefixed",
+			encoder: prefixed,
+			a:       "Van der Berg",
+			b:       "Berg",
+			want:    MATCH_NORMAL,
+		},
+		{
+			name:    "test MacDonald plain",
+			encoder: plain,
+			a:       "MacDonald",
+			b:       "Donald",
+			want:    MATCH_NONE,
+		},
+		{
+			name:    "test MacDonald stripped",
+			encoder: stripped,
+			a:       "MacDonald",
+			b:       "Donald",
+			want:    MATCH_STRONG,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareWith(tt.encoder, tt.a, tt.b); got != tt.want {
+				t.Errorf("TestPrefixPolicyEncoder = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrefixPolicyEncoderKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want []string
+	}{
+		{
+			name: "test De la Cruz",
+			arg:  "De la Cruz",
+			want: []string{"TLKRS", "KRS"},
+		},
+		{
+			name: "test D'Angelo",
+			arg:  "D'Angelo",
+			want: []string{"TNJL", "TNKL", "ANJL", "ANKL"},
+		},
+		{
+			name: "test Van Wagner",
+			arg:  "Van Wagner",
+			want: []string{"FNKNR", "AKNR", "FKNR"},
+		},
+		{
+			name: "test no prefix",
+			arg:  "Smith",
+			want: []string{"SM0", "XMT"},
+		},
+	}
+	encoder := DoubleMetaphoneEncoder{Prefixes: DefaultPrefixPolicy()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encoder.Keys(tt.arg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestPrefixPolicyEncoderKeys = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -18,12 +18,15 @@ package godoublemetaphone
  */
 
 const (
-	METAPHONE_KEY_LENGTH = 6 //The length of the metaphone keys produced.  4 is sweet spot
+	METAPHONE_KEY_LENGTH       = 6 //The length of the metaphone keys produced.  4 is sweet spot
+	METAPHONE_SHORT_KEY_LENGTH = 4 //The number of key characters that fit, one nibble each, in a uint16
 )
 
 type ShortDoubleMetaphone interface {
 	PrimaryShortKey() uint16
 	AlternateShortKey() uint16
+	PrimaryPackedKey() uint32
+	AlternatePackedKey() uint32
 }
 
 const (
@@ -54,6 +57,9 @@ const (
 
 	/// Sentinel value, used to denote an invalid key
 	METAPHONE_INVALID_KEY uint16 = 0xffff
+
+	/// Sentinel value, used to denote an invalid packed key
+	METAPHONE_INVALID_PACKED_KEY uint32 = 0xffffffff
 )
 
 /// <summary>Subclass of DoubleMetaphone, Adam Nelson's (anelson@nullpointer.net)
@@ -69,6 +75,10 @@ type shortDoubleMetaphone struct {
 	/// The ushort versions of the primary and alternate keys
 	primaryShortKey   uint16
 	alternateShortKey uint16
+
+	/// The uint32 versions of the primary and alternate keys, holding all METAPHONE_KEY_LENGTH characters
+	primaryPackedKey   uint32
+	alternatePackedKey uint32
 }
 
 /// <summary>Initializes the base class with the given word, then computes
@@ -78,14 +88,17 @@ type shortDoubleMetaphone struct {
 /// <param name="word">Word for which to compute metaphone keys</param>
 func NewShortDoubleMetaphone(word string) ShortDoubleMetaphone {
 	sdm := &shortDoubleMetaphone{
-		dm: newDoubleMetaphone(word, METAPHONE_KEY_LENGTH),
+		dm: newDoubleMetaphone(word, METAPHONE_KEY_LENGTH, FOLD_NONE),
 	}
 
 	sdm.primaryShortKey = sdm.metaphoneKeyToShort(sdm.dm.PrimaryKey())
+	sdm.primaryPackedKey = metaphoneKeyToPacked(sdm.dm.PrimaryKey())
 	if sdm.dm.AlternateKey() != nil {
 		sdm.alternateShortKey = sdm.metaphoneKeyToShort(*sdm.dm.AlternateKey())
+		sdm.alternatePackedKey = metaphoneKeyToPacked(*sdm.dm.AlternateKey())
 	} else {
 		sdm.alternateShortKey = METAPHONE_INVALID_KEY
+		sdm.alternatePackedKey = METAPHONE_INVALID_PACKED_KEY
 	}
 
 	return sdm
@@ -102,55 +115,114 @@ func (sdm *shortDoubleMetaphone) AlternateShortKey() uint16 {
 	return sdm.alternateShortKey
 }
 
+/// <summary>The primary metaphone key, represented as a uint32 holding every character of the key</summary>
+func (sdm *shortDoubleMetaphone) PrimaryPackedKey() uint32 {
+	return sdm.primaryPackedKey
+}
+
+/// <summary>The alternative metaphone key represented as a uint32, or METAPHONE_INVALID_PACKED_KEY
+///     if the current word has no alternate key by double metaphone</summary>
+func (sdm *shortDoubleMetaphone) AlternatePackedKey() uint32 {
+	return sdm.alternatePackedKey
+}
+
 /// <summary>Represents a string metaphone key as a ushort</summary>
 ///
-/// <param name="metaphoneKey">String metaphone key.  Must be four chars long; if you change
-///     METAPHONE_KEY_LENGTH in DoubleMetaphone, this will break.  Length
-///     tests are not performed, for performance reasons.</param>
+/// <param name="metaphoneKey">String metaphone key.  Only the first METAPHONE_SHORT_KEY_LENGTH
+///     characters fit in a ushort, so longer keys are truncated; use the packed key to
+///     keep all METAPHONE_KEY_LENGTH characters</param>
 ///
 /// <returns>ushort representation of the given metahphone key</returns>
 func (sdm *shortDoubleMetaphone) metaphoneKeyToShort(metaphoneKey string) uint16 {
-	var result, charResult uint16
-	var currentChar rune
+	if len(metaphoneKey) > METAPHONE_SHORT_KEY_LENGTH {
+		metaphoneKey = metaphoneKey[:METAPHONE_SHORT_KEY_LENGTH]
+	}
 
-	for currentCharIdx := 0; currentCharIdx < len(metaphoneKey); currentCharIdx++ {
-		currentChar = rune(metaphoneKey[currentCharIdx])
-		if currentChar == 'A' {
-			charResult = METAPHONE_A
-		} else if currentChar == 'P' {
-			charResult = METAPHONE_P
-		} else if currentChar == 'S' {
-			charResult = METAPHONE_S
-		} else if currentChar == 'K' {
-			charResult = METAPHONE_K
-		} else if currentChar == 'X' {
-			charResult = METAPHONE_X
-		} else if currentChar == 'J' {
-			charResult = METAPHONE_J
-		} else if currentChar == 'T' {
-			charResult = METAPHONE_T
-		} else if currentChar == 'F' {
-			charResult = METAPHONE_F
-		} else if currentChar == 'N' {
-			charResult = METAPHONE_N
-		} else if currentChar == 'H' {
-			charResult = METAPHONE_H
-		} else if currentChar == 'M' {
-			charResult = METAPHONE_M
-		} else if currentChar == 'L' {
-			charResult = METAPHONE_L
-		} else if currentChar == 'R' {
-			charResult = METAPHONE_R
-		} else if currentChar == ' ' {
-			charResult = METAPHONE_SPACE
-		} else if currentChar == '\x00' {
-			charResult = METAPHONE_0
-		} else {
-			charResult = 0x00 //This should never happen
-		}
+	return uint16(metaphoneKeyToPacked(metaphoneKey))
+}
+
+/// The characters the string encoder can emit, and the nibble representing each in a short or packed key
+var metaphoneCharacterCodes = []struct {
+	character byte
+	code      uint16
+}{
+	{'A', METAPHONE_A},
+	{'F', METAPHONE_F},
+	{'H', METAPHONE_H},
+	{'J', METAPHONE_J},
+	{'K', METAPHONE_K},
+	{'L', METAPHONE_L},
+	{'M', METAPHONE_M},
+	{'N', METAPHONE_N},
+	{'P', METAPHONE_P},
+	{'S', METAPHONE_S},
+	{'T', METAPHONE_T},
+	{'R', METAPHONE_R},
+	{'X', METAPHONE_X},
+	{'0', METAPHONE_0}, //theta, emitted for 'TH'
+	{' ', METAPHONE_SPACE},
+}
+
+/// Lookup tables built from metaphoneCharacterCodes, indexed by character and by nibble
+var characterToCode, codeToCharacter = func() ([256]uint16, [16]byte) {
+	var toCode [256]uint16
+	var toCharacter [16]byte
+	for _, entry := range metaphoneCharacterCodes {
+		toCode[entry.character] = entry.code
+		toCharacter[entry.code] = entry.character
+	}
+	return toCode, toCharacter
+}()
+
+/// <summary>Represents a string metaphone key as a uint32, one nibble per character</summary>
+///
+/// <param name="metaphoneKey">String metaphone key.  At most eight characters fit in a uint32,
+///     which covers METAPHONE_KEY_LENGTH; length tests are not performed, for performance reasons.</param>
+///
+/// <returns>uint32 representation of the given metahphone key</returns>
+func metaphoneKeyToPacked(metaphoneKey string) uint32 {
+	var result uint32
 
+	for currentCharIdx := 0; currentCharIdx < len(metaphoneKey); currentCharIdx++ {
+		//characters the encoder never emits map to METAPHONE_NULL
 		result <<= 4
-		result |= charResult
+		result |= uint32(characterToCode[metaphoneKey[currentCharIdx]])
 	}
 	return result
 }
+
+/// <summary>Converts a short metaphone key back to its string representation</summary>
+///
+/// <param name="shortKey">Key as returned by PrimaryShortKey or AlternateShortKey</param>
+///
+/// <returns>String metaphone key, or an empty string for METAPHONE_INVALID_KEY</returns>
+func ShortKeyToString(shortKey uint16) string {
+	if shortKey == METAPHONE_INVALID_KEY {
+		return ""
+	}
+
+	return PackedKeyToString(uint32(shortKey))
+}
+
+/// <summary>Converts a packed metaphone key back to its string representation</summary>
+///
+/// <param name="packedKey">Key as returned by PrimaryPackedKey or AlternatePackedKey</param>
+///
+/// <returns>String metaphone key, or an empty string for METAPHONE_INVALID_PACKED_KEY</returns>
+func PackedKeyToString(packedKey uint32) string {
+	if packedKey == METAPHONE_INVALID_PACKED_KEY {
+		return ""
+	}
+
+	var key []byte
+	for shift := 28; shift >= 0; shift -= 4 {
+		nibble := (packedKey >> uint(shift)) & 0x0F
+		if uint16(nibble) == METAPHONE_NULL && len(key) == 0 {
+			//leading empty nibbles, the key is shorter than eight characters
+			continue
+		}
+		key = append(key, codeToCharacter[nibble])
+	}
+
+	return string(key)
+}
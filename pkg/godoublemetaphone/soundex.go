@@ -0,0 +1,182 @@
+package godoublemetaphone
+
+import (
+	"unicode"
+)
+
+/**
+ * soundex.go
+ *
+ * American Soundex, as used by the U.S. National Archives for census indexes, and Refined
+ * Soundex.  Only the letters A-Z are coded; fold a word with FoldWord first to code
+ * accented letters.
+ */
+
+const (
+	SOUNDEX_KEY_LENGTH = 4 //Length of an American Soundex code, a letter and three digits
+)
+
+/// American Soundex digit for each letter A-Z, '0' for letters that are not coded
+const soundexCodes = "01230120022455012623010202"
+
+/// Refined Soundex digit for each letter A-Z
+const refinedSoundexCodes = "01360240043788015936020505"
+
+/// Surname prefixes the National Archives code both with and without
+var soundexPrefixes = []string{"VAN", "VON", "CON", "DE", "DI", "DU", "LA", "LE"}
+
+/// <summary>Computes the American Soundex code of a word: its first letter followed by
+///     three digits, padded with zeros.  Letters with the same digit separated only by H or W
+///     are coded once, and the first letter counts as coded</summary>
+///
+/// <returns>The code, or an empty string if the word has no letters A-Z</returns>
+func Soundex(word string) string {
+	return soundexLetters(asciiUpperLetters(word))
+}
+
+/// <summary>Computes the American Soundex codes of a surname: the code of the whole name,
+///     followed by the code without its prefix if it starts with Van, Von, Con, De, Di, Du,
+///     La or Le.  The prefix must be followed by a space, apostrophe or hyphen, or, if the
+///     prefix is written in mixed case, by an upper case letter as in 'VanDeusen', so names
+///     like 'Dean' or 'DEAN' are not split</summary>
+func SoundexVariants(word string) []string {
+	letters := asciiUpperLetters(word)
+	code := soundexLetters(letters)
+	if code == "" {
+		return []string{}
+	}
+
+	codes := []string{code}
+	if rest := soundexWithoutPrefix(word); rest != nil {
+		if restCode := soundexLetters(rest); restCode != "" && restCode != code {
+			codes = append(codes, restCode)
+		}
+	}
+
+	return codes
+}
+
+/// <summary>Computes the Refined Soundex code of a word: its first letter followed by a
+///     digit for every letter, including the first, with adjacent duplicate digits collapsed.
+///     The code is not truncated or padded</summary>
+///
+/// <returns>The code, or an empty string if the word has no letters A-Z</returns>
+func RefinedSoundex(word string) string {
+	letters := asciiUpperLetters(word)
+	if len(letters) == 0 {
+		return ""
+	}
+
+	code := []byte{letters[0]}
+	var last byte
+	for _, letter := range letters {
+		digit := refinedSoundexCodes[letter-'A']
+		if digit != last {
+			code = append(code, digit)
+		}
+		last = digit
+	}
+
+	return string(code)
+}
+
+/// SoundexEncoder is the PhoneticEncoder for American Soundex; the keys are those of SoundexVariants
+type SoundexEncoder struct{}
+
+func (SoundexEncoder) Keys(word string) []string {
+	return SoundexVariants(word)
+}
+
+/// RefinedSoundexEncoder is the PhoneticEncoder for Refined Soundex
+type RefinedSoundexEncoder struct{}
+
+func (RefinedSoundexEncoder) Keys(word string) []string {
+	return []string{RefinedSoundex(word)}
+}
+
+func soundexLetters(letters []byte) string {
+	if len(letters) == 0 {
+		return ""
+	}
+
+	code := make([]byte, 0, SOUNDEX_KEY_LENGTH)
+	code = append(code, letters[0])
+	last := soundexCodes[letters[0]-'A']
+	for _, letter := range letters[1:] {
+		if len(code) == SOUNDEX_KEY_LENGTH {
+			break
+		}
+
+		digit := soundexCodes[letter-'A']
+		switch {
+		case letter == 'H' || letter == 'W':
+			//do not separate letters with the same digit
+		case digit == '0':
+			//vowels separate letters with the same digit
+			last = digit
+		case digit != last:
+			code = append(code, digit)
+			last = digit
+		}
+	}
+
+	for len(code) < SOUNDEX_KEY_LENGTH {
+		code = append(code, '0')
+	}
+
+	return string(code)
+}
+
+/// <summary>The letters A-Z of word, upper cased; everything else is dropped</summary>
+func asciiUpperLetters(word string) []byte {
+	letters := make([]byte, 0, len(word))
+	for _, r := range word {
+		r = unicode.ToUpper(r)
+		if r >= 'A' && r <= 'Z' {
+			letters = append(letters, byte(r))
+		}
+	}
+
+	return letters
+}
+
+/// <summary>Whether runes has both upper and lower case letters</summary>
+func isMixedCase(runes []rune) bool {
+	upper, lower := false, false
+	for _, r := range runes {
+		upper = upper || unicode.IsUpper(r)
+		lower = lower || unicode.IsLower(r)
+	}
+
+	return upper && lower
+}
+
+/// <summary>The letters of word after a surname prefix, or nil if it has none</summary>
+func soundexWithoutPrefix(word string) []byte {
+	runes := []rune(word)
+	for _, prefix := range soundexPrefixes {
+		if len(runes) <= len(prefix) {
+			continue
+		}
+
+		matched := true
+		for idx := 0; idx < len(prefix); idx++ {
+			if unicode.ToUpper(runes[idx]) != rune(prefix[idx]) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		next := runes[len(prefix)]
+		if next == ' ' || next == '\'' || next == '-' || next == '’' || (unicode.IsUpper(next) && isMixedCase(runes[:len(prefix)])) {
+			if rest := asciiUpperLetters(string(runes[len(prefix):])); len(rest) > 0 {
+				return rest
+			}
+		}
+	}
+
+	return nil
+}
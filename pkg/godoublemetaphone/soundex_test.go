@@ -0,0 +1,156 @@
+package godoublemetaphone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSoundex(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{name: "test Robert", arg: "Robert", want: "R163"},
+		{name: "test Rupert", arg: "Rupert", want: "R163"},
+		{name: "test Rubin", arg: "Rubin", want: "R150"},
+		{name: "test Ashcraft H between same codes", arg: "Ashcraft", want: "A261"},
+		{name: "test Ashcroft", arg: "Ashcroft", want: "A261"},
+		{name: "test Tymczak vowel between same codes", arg: "Tymczak", want: "T522"},
+		{name: "test Pfister first letter coded", arg: "Pfister", want: "P236"},
+		{name: "test Honeyman", arg: "Honeyman", want: "H555"},
+		{name: "test Jackson", arg: "Jackson", want: "J250"},
+		{name: "test Washington", arg: "Washington", want: "W252"},
+		{name: "test Lee padded", arg: "Lee", want: "L000"},
+		{name: "test Gutierrez", arg: "Gutierrez", want: "G362"},
+		{name: "test lower case and punctuation", arg: "o'hara", want: "O600"},
+		{name: "test no letters", arg: "123", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Soundex(tt.arg); got != tt.want {
+				t.Errorf("Soundex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSoundexVariants(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want []string
+	}{
+		{name: "test Van Deusen", arg: "Van Deusen", want: []string{"V532", "D250"}},
+		{name: "test VanDeusen", arg: "VanDeusen", want: []string{"V532", "D250"}},
+		{name: "test De'Angelo", arg: "De'Angelo", want: []string{"D524", "A524"}},
+		{name: "test LaFleur", arg: "LaFleur", want: []string{"L146", "F460"}},
+		{name: "test Dean is not a prefix", arg: "Dean", want: []string{"D500"}},
+		{name: "test Lebowski is not a prefix", arg: "Lebowski", want: []string{"L120"}},
+		{name: "test DEAN all caps is not a prefix", arg: "DEAN", want: []string{"D500"}},
+		{name: "test VANCE all caps is not a prefix", arg: "VANCE", want: []string{"V520"}},
+		{name: "test DIXON all caps is not a prefix", arg: "DIXON", want: []string{"D250"}},
+		{name: "test LEE all caps is not a prefix", arg: "LEE", want: []string{"L000"}},
+		{name: "test VAN DEUSEN all caps", arg: "VAN DEUSEN", want: []string{"V532", "D250"}},
+		{name: "test DE'ANGELO all caps", arg: "DE'ANGELO", want: []string{"D524", "A524"}},
+		{name: "test no letters", arg: "", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SoundexVariants(tt.arg); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("SoundexVariants() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefinedSoundex(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{name: "test testing", arg: "testing", want: "T6036084"},
+		{name: "test The", arg: "The", want: "T60"},
+		{name: "test quick", arg: "quick", want: "Q503"},
+		{name: "test brown", arg: "brown", want: "B1908"},
+		{name: "test fox", arg: "fox", want: "F205"},
+		{name: "test jumped", arg: "jumped", want: "J408106"},
+		{name: "test over", arg: "over", want: "O0209"},
+		{name: "test lazy", arg: "lazy", want: "L7050"},
+		{name: "test dogs", arg: "dogs", want: "D6043"},
+		{name: "test no letters", arg: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RefinedSoundex(tt.arg); got != tt.want {
+				t.Errorf("RefinedSoundex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhoneticEncoders(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoder PhoneticEncoder
+		arg     string
+		want    []string
+	}{
+		{name: "test double metaphone", encoder: DoubleMetaphoneEncoder{}, arg: "Schmidt", want: []string{"XMT", "SMT"}},
+		{name: "test double metaphone limit", encoder: DoubleMetaphoneEncoder{MaxKeyLength: 2}, arg: "Schmidt", want: []string{"XM", "SM"}},
+		{name: "test double metaphone no alternate", encoder: DoubleMetaphoneEncoder{}, arg: "Peace", want: []string{"PS"}},
+		{name: "test double metaphone unmatched prefix policy", encoder: DoubleMetaphoneEncoder{Prefixes: DefaultPrefixPolicy()}, arg: "Schmidt", want: []string{"XMT", "SMT"}},
+		{name: "test metaphone", encoder: MetaphoneEncoder{}, arg: "testing", want: []string{"TSTNK"}},
+		{name: "test soundex", encoder: SoundexEncoder{}, arg: "Van Deusen", want: []string{"V532", "D250"}},
+		{name: "test refined soundex", encoder: RefinedSoundexEncoder{}, arg: "testing", want: []string{"T6036084"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.encoder.Keys(tt.arg); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Keys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhoneticIndexEncoder(t *testing.T) {
+	idx := NewPhoneticIndexEncoder[int](SoundexEncoder{})
+	idx.Add(1, "Robert")
+	idx.Add(2, "Van Deusen")
+	idx.Add(3, "Deusen")
+	idx.Add(4, "")
+
+	if got, want := fmt.Sprint(idx.Lookup("Rupert")), fmt.Sprint([]IndexMatch[int]{{ID: 1, Level: MATCH_STRONG}}); got != want {
+		t.Errorf("Lookup(Rupert) = %v, want %v", got, want)
+	}
+	if got, want := fmt.Sprint(idx.Lookup("Deusen")), fmt.Sprint([]IndexMatch[int]{{ID: 3, Level: MATCH_STRONG}, {ID: 2, Level: MATCH_NORMAL}}); got != want {
+		t.Errorf("Lookup(Deusen) = %v, want %v", got, want)
+	}
+	if got := idx.Lookup(""); len(got) != 0 {
+		t.Errorf("Lookup('') = %v, want none", got)
+	}
+}
+
+func TestCompareWith(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoder PhoneticEncoder
+		a, b    string
+		want    MatchLevel
+	}{
+		{name: "test soundex strong", encoder: SoundexEncoder{}, a: "Robert", b: "Rupert", want: MATCH_STRONG},
+		{name: "test soundex prefix normal", encoder: SoundexEncoder{}, a: "Van Deusen", b: "Deusen", want: MATCH_NORMAL},
+		{name: "test soundex none", encoder: SoundexEncoder{}, a: "Robert", b: "Rubin", want: MATCH_NONE},
+		{name: "test double metaphone agrees with CompareWords", encoder: DoubleMetaphoneEncoder{}, a: "Smith", b: "Schmidt", want: CompareWords("Smith", "Schmidt")},
+		{name: "test double metaphone weak", encoder: DoubleMetaphoneEncoder{}, a: "Wewski", b: "Vewski", want: MATCH_WEAK},
+		{name: "test empty", encoder: SoundexEncoder{}, a: "", b: "Robert", want: MATCH_NONE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareWith(tt.encoder, tt.a, tt.b); got != tt.want {
+				t.Errorf("CompareWith() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package godoublemetaphone
+
+import (
+	"math"
+)
+
+/**
+ * spanish.go
+ *
+ * A phonetic key for Spanish names, in the spirit of the Spanish phonetic ('fonético')
+ * algorithms, for Latin American pronunciation: B, V and W merge, LL and consonant Y merge
+ * (yeísmo), H is silent, C before E or I, Z and S merge (seseo), J and G before E or I
+ * merge, QU and GU before E or I are K and G, and Ñ is coded as N so names typed without
+ * it still match.  Vowels are only kept at the start of the name, coded as 'A', and
+ * repeated sounds are coded once.  Accents are ignored.
+ */
+
+type SpanishPhonetic interface {
+	Key() string
+	Word() string
+}
+
+type spanishPhonetic struct {
+	maxKeyLength int
+
+	///Letters of the word without accents, except Ñ
+	letters []rune
+
+	key          []byte
+	keyString    string
+	originalWord string
+}
+
+func NewSpanishPhonetic(word string) SpanishPhonetic {
+	return newSpanishPhonetic(word, math.MaxInt64)
+}
+
+func NewSpanishPhoneticLimit(word string, maxKeyLength int) SpanishPhonetic {
+	return newSpanishPhonetic(word, keyLengthOrUnlimited(maxKeyLength))
+}
+
+func newSpanishPhonetic(word string, maxKeyLength int) *spanishPhonetic {
+	sp := &spanishPhonetic{
+		maxKeyLength: maxKeyLength,
+		originalWord: word,
+	}
+
+	runes, length := prepareWord(nil, word, FOLD_COMPOSE)
+	for _, r := range runes[:length] {
+		if letter, ok := spanishLetter(r); ok {
+			sp.letters = append(sp.letters, letter)
+		}
+	}
+
+	sp.buildKey()
+	sp.keyString = string(sp.key)
+
+	return sp
+}
+
+/// <summary>The Spanish phonetic key for the word, empty if it has no letters</summary>
+func (sp *spanishPhonetic) Key() string {
+	return sp.keyString
+}
+
+/// <summary>Original word for which the key was computed</summary>
+func (sp *spanishPhonetic) Word() string {
+	return sp.originalWord
+}
+
+/// SpanishPhoneticEncoder is the PhoneticEncoder for the Spanish phonetic key.  The zero
+/// value produces keys of unlimited length
+type SpanishPhoneticEncoder struct {
+	MaxKeyLength int
+}
+
+func (enc SpanishPhoneticEncoder) Keys(word string) []string {
+	return []string{newSpanishPhonetic(word, keyLengthOrUnlimited(enc.MaxKeyLength)).keyString}
+}
+
+/// <summary>Upper case letter without its accent; Ñ is kept.  false for anything that is
+///     not a letter of the Spanish alphabet</summary>
+func spanishLetter(r rune) (rune, bool) {
+	switch r {
+	case 'Á', 'À':
+		return 'A', true
+	case 'É', 'È':
+		return 'E', true
+	case 'Í', 'Ï':
+		return 'I', true
+	case 'Ó':
+		return 'O', true
+	case 'Ú', 'Ü':
+		return 'U', true
+	case 'Ñ':
+		return 'Ñ', true
+	}
+
+	return r, r >= 'A' && r <= 'Z'
+}
+
+func isSpanishVowel(r rune) bool {
+	return r == 'A' || r == 'E' || r == 'I' || r == 'O' || r == 'U'
+}
+
+/// <summary>Letter at position, or a space past the end</summary>
+func (sp *spanishPhonetic) letterAt(position int) rune {
+	if position < len(sp.letters) {
+		return sp.letters[position]
+	}
+
+	return ' '
+}
+
+/// <summary>Adds a code unless it repeats the previous one</summary>
+func (sp *spanishPhonetic) addCode(codes string) {
+	for idx := 0; idx < len(codes); idx++ {
+		if len(sp.key) > 0 && sp.key[len(sp.key)-1] == codes[idx] {
+			continue
+		}
+		sp.key = append(sp.key, codes[idx])
+	}
+}
+
+/**
+* Internal impl of the Spanish phonetic key.  Populates sp.key
+ */
+func (sp *spanishPhonetic) buildKey() {
+	for current := 0; current < len(sp.letters) && len(sp.key) < sp.maxKeyLength; current++ {
+		letter, next := sp.letterAt(current), sp.letterAt(current+1)
+		softNext := next == 'E' || next == 'I'
+
+		switch letter {
+		case 'A', 'E', 'I', 'O', 'U':
+			//vowels are only kept at the start of the name, also after a silent H
+			if current == 0 || current == 1 && sp.letters[0] == 'H' {
+				sp.addCode("A")
+			}
+		case 'Y':
+			//Y before a vowel is a consonant, like LL
+			if isSpanishVowel(next) {
+				sp.addCode("Y")
+			} else if current == 0 {
+				sp.addCode("A")
+			}
+		case 'B', 'V', 'W':
+			sp.addCode("B")
+		case 'C':
+			switch {
+			case next == 'H':
+				sp.addCode("X")
+				current++
+			case softNext:
+				sp.addCode("S")
+			default:
+				sp.addCode("K")
+			}
+		case 'Z', 'S':
+			sp.addCode("S")
+		case 'Q':
+			if next == 'U' {
+				current++
+			}
+			sp.addCode("K")
+		case 'G':
+			switch {
+			case softNext:
+				sp.addCode("J")
+			case next == 'U' && (sp.letterAt(current+2) == 'E' || sp.letterAt(current+2) == 'I'):
+				//the U of GUE and GUI is silent
+				sp.addCode("G")
+				current++
+			default:
+				sp.addCode("G")
+			}
+		case 'H':
+			//silent
+		case 'L':
+			if next == 'L' {
+				sp.addCode("Y")
+				current++
+			} else {
+				sp.addCode("L")
+			}
+		case 'Ñ':
+			sp.addCode("N")
+		case 'X':
+			//initial X is the old spelling of J, e.g. 'Ximénez'
+			if current == 0 {
+				sp.addCode("J")
+			} else {
+				sp.addCode("KS")
+			}
+		case 'P':
+			if next == 'H' {
+				sp.addCode("F")
+				current++
+			} else {
+				sp.addCode("P")
+			}
+		default:
+			sp.addCode(string(letter))
+		}
+	}
+
+	if len(sp.key) > sp.maxKeyLength {
+		sp.key = sp.key[:sp.maxKeyLength]
+	}
+}
@@ -0,0 +1,79 @@
+package godoublemetaphone
+
+import (
+	"testing"
+)
+
+func TestSpanishPhonetic(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{name: "test B V merge", arg: "Vázquez", want: "BSKS"},
+		{name: "test Bazquez", arg: "Bazquez", want: "BSKS"},
+		{name: "test Velázquez", arg: "Velázquez", want: "BLSKS"},
+		{name: "test LL", arg: "Llorente", want: "YRNT"},
+		{name: "test consonant Y", arg: "Yorente", want: "YRNT"},
+		{name: "test vowel Y", arg: "Rey", want: "R"},
+		{name: "test silent H", arg: "Hernández", want: "ARNDS"},
+		{name: "test without H", arg: "Ernandes", want: "ARNDS"},
+		{name: "test seseo", arg: "Cecilia", want: "SL"},
+		{name: "test seseo S", arg: "Sesilia", want: "SL"},
+		{name: "test J", arg: "Jiménez", want: "JMNS"},
+		{name: "test soft G", arg: "Giménez", want: "JMNS"},
+		{name: "test initial X", arg: "Ximénez", want: "JMNS"},
+		{name: "test GUE", arg: "Guevara", want: "GBR"},
+		{name: "test GÜE", arg: "Agüero", want: "AGR"},
+		{name: "test QU", arg: "Quintero", want: "KNTR"},
+		{name: "test Ñ", arg: "Núñez", want: "NS"},
+		{name: "test RR", arg: "Herrera", want: "AR"},
+		{name: "test CH", arg: "Chávez", want: "XBS"},
+		{name: "test CC", arg: "Acción", want: "AKSN"},
+		{name: "test words", arg: "San Jacinto", want: "SNJSNT"},
+		{name: "test no letters", arg: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := NewSpanishPhonetic(tt.arg)
+			if got := sp.Key(); got != tt.want {
+				t.Errorf("NewSpanishPhonetic() = %v, want %v", got, tt.want)
+			}
+			if sp.Word() != tt.arg {
+				t.Errorf("Word() = %v, want %v", sp.Word(), tt.arg)
+			}
+		})
+	}
+
+	if got, want := NewSpanishPhoneticLimit("Velázquez", 3).Key(), "BLS"; got != want {
+		t.Errorf("NewSpanishPhoneticLimit() = %v, want %v", got, want)
+	}
+}
+
+func TestSpanishSpellingVariants(t *testing.T) {
+	//spellings a Latin American reader pronounces alike; Double Metaphone does not match all of them
+	tests := []struct {
+		name        string
+		a, b        string
+		wantDouble  MatchLevel
+		wantSpanish MatchLevel
+	}{
+		{name: "test Vázquez Bazquez", a: "Vázquez", b: "Bazquez", wantDouble: MATCH_NONE, wantSpanish: MATCH_STRONG},
+		{name: "test Llorente Yorente", a: "Llorente", b: "Yorente", wantDouble: MATCH_NONE, wantSpanish: MATCH_STRONG},
+		{name: "test Castillo Castiyo", a: "Castillo", b: "Castiyo", wantDouble: MATCH_NORMAL, wantSpanish: MATCH_STRONG},
+		{name: "test Hernández Ernandes", a: "Hernández", b: "Ernandes", wantDouble: MATCH_NONE, wantSpanish: MATCH_STRONG},
+		{name: "test Jiménez Ximénez", a: "Jiménez", b: "Ximénez", wantDouble: MATCH_NONE, wantSpanish: MATCH_STRONG},
+		{name: "test Cecilia Sesilia", a: "Cecilia", b: "Sesilia", wantDouble: MATCH_STRONG, wantSpanish: MATCH_STRONG},
+		{name: "test Guevara Jevara", a: "Guevara", b: "Jevara", wantDouble: MATCH_NONE, wantSpanish: MATCH_NONE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareWith(DoubleMetaphoneEncoder{}, tt.a, tt.b); got != tt.wantDouble {
+				t.Errorf("double metaphone = %v, want %v", got, tt.wantDouble)
+			}
+			if got := CompareWith(SpanishPhoneticEncoder{}, tt.a, tt.b); got != tt.wantSpanish {
+				t.Errorf("Spanish = %v, want %v", got, tt.wantSpanish)
+			}
+		})
+	}
+}